@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// Key types of the accounts of a source chain.
+const (
+	KeyTypeSecp256k1    = "secp256k1"
+	KeyTypeEthSecp256k1 = "eth_secp256k1"
+)
+
+// Handling of address conversions the owner may not control.
+const (
+	UnsafeAddressReject = "reject"
+	UnsafeAddressFlag   = "flag"
+)
+
+// SourceChain is the HD coin type and key type the accounts of a chain are
+// derived with.
+type SourceChain struct {
+	Prefix   string `yaml:"prefix" json:"prefix"`
+	CoinType uint32 `yaml:"coin_type" json:"coin_type"`
+	KeyType  string `yaml:"key_type" json:"key_type"` // default secp256k1
+}
+
+// DefaultSourceChains returns the builtin registry of source chains by account
+// address prefix.
+func DefaultSourceChains() map[string]SourceChain {
+	chains := map[string]SourceChain{}
+	for _, c := range []SourceChain{
+		{Prefix: "cosmos", CoinType: 118},
+		{Prefix: "cre", CoinType: 118},
+		{Prefix: "osmo", CoinType: 118},
+		{Prefix: "juno", CoinType: 118},
+		{Prefix: "akash", CoinType: 118},
+		{Prefix: "stars", CoinType: 118},
+		{Prefix: "umee", CoinType: 118},
+		{Prefix: "gravity", CoinType: 118},
+		{Prefix: "regen", CoinType: 118},
+		{Prefix: "sent", CoinType: 118},
+		{Prefix: "terra", CoinType: 330},
+		{Prefix: "kava", CoinType: 459},
+		{Prefix: "secret", CoinType: 529},
+		{Prefix: "cro", CoinType: 394},
+		{Prefix: "band", CoinType: 494},
+		{Prefix: "evmos", CoinType: 60, KeyType: KeyTypeEthSecp256k1},
+		{Prefix: "inj", CoinType: 60, KeyType: KeyTypeEthSecp256k1},
+	} {
+		c.KeyType = keyTypeOrDefault(c.KeyType)
+		chains[c.Prefix] = c
+	}
+	return chains
+}
+
+func keyTypeOrDefault(keyType string) string {
+	if keyType == "" {
+		return KeyTypeSecp256k1
+	}
+	return keyType
+}
+
+// AddressPolicy converts the addresses of the airdrop and vesting files to the
+// account address prefix. The converted address is only controlled by the
+// owner if the source chain derives its keys with the coin type and key type of
+// this chain. Other conversions, including those of prefixes missing from the
+// registry, are rejected or flagged.
+type AddressPolicy struct {
+	Chains      map[string]SourceChain // by account address prefix
+	Flag        bool                   // flag unsafe conversions instead of rejecting them
+	Allow32Byte bool                   // accept 32-byte module and contract addresses
+}
+
+// DefaultAddressPolicy rejects unsafe conversions of the builtin registry and
+// 32-byte addresses.
+func DefaultAddressPolicy() *AddressPolicy {
+	return &AddressPolicy{Chains: DefaultSourceChains()}
+}
+
+// FlaggedAddress is a row whose address was converted although its owner may
+// not control the converted address.
+type FlaggedAddress struct {
+	Row       int    `json:"row"`
+	Address   string `json:"address"`
+	Converted string `json:"converted"`
+	Reason    string `json:"reason"`
+}
+
+// Convert re-encodes addr with the account address prefix. The reason is not
+// empty if the conversion is unsafe but flagged instead of rejected.
+func (p *AddressPolicy) Convert(addr string) (converted string, reason string, err error) {
+	if p == nil {
+		p = DefaultAddressPolicy()
+	}
+	prefix, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return "", "", err
+	}
+	if len(bz) == 32 && !p.Allow32Byte {
+		return "", "", fmt.Errorf("32-byte module or contract address is not allowed")
+	}
+	converted, err = bech32.ConvertAndEncode(sdk.GetConfig().GetBech32AccountAddrPrefix(), bz)
+	if err != nil {
+		return "", "", err
+	}
+
+	reason = p.unsafeReason(prefix)
+	if reason != "" && !p.Flag {
+		return "", "", fmt.Errorf("%s, the owner may not control %s", reason, converted)
+	}
+	return converted, reason, nil
+}
+
+// unsafeReason returns why an address of the prefix may not be controlled by
+// its owner once converted, or an empty string.
+func (p *AddressPolicy) unsafeReason(prefix string) string {
+	config := sdk.GetConfig()
+	if prefix == config.GetBech32AccountAddrPrefix() {
+		return ""
+	}
+	chain, ok := p.Chains[prefix]
+	if !ok {
+		return fmt.Sprintf("unknown source chain prefix %s", prefix)
+	}
+	if chain.CoinType != config.GetCoinType() {
+		return fmt.Sprintf("%s uses coin type %d instead of %d", prefix, chain.CoinType, config.GetCoinType())
+	}
+	if keyType := keyTypeOrDefault(chain.KeyType); keyType != KeyTypeSecp256k1 {
+		return fmt.Sprintf("%s uses %s keys", prefix, keyType)
+	}
+	return ""
+}
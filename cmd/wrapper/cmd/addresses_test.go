@@ -0,0 +1,65 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestAddressPolicy(t *testing.T) {
+	useCrescentConfig(t)
+
+	encode := func(prefix string, size int) string {
+		addr, err := bech32.ConvertAndEncode(prefix, bytes.Repeat([]byte{1}, size))
+		require.NoError(t, err)
+		return addr
+	}
+	converted := encode("cre", 20)
+
+	policy := cmd.DefaultAddressPolicy()
+	addr, reason, err := policy.Convert(encode("osmo", 20))
+	require.NoError(t, err)
+	require.Equal(t, converted, addr)
+	require.Empty(t, reason)
+
+	// Coin type 330 and 60 chains, unknown prefixes and 32-byte addresses are rejected
+	for _, addr := range []string{encode("terra", 20), encode("evmos", 20), encode("unknown", 20), encode("cosmos", 32)} {
+		_, _, err = policy.Convert(addr)
+		require.Error(t, err, addr)
+	}
+
+	// or flagged and allowed
+	policy.Flag = true
+	policy.Allow32Byte = true
+	addr, reason, err = policy.Convert(encode("terra", 20))
+	require.NoError(t, err)
+	require.Equal(t, converted, addr)
+	require.Contains(t, reason, "coin type 330")
+	_, reason, err = policy.Convert(encode("cosmos", 32))
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	kava, err := bech32.ConvertAndEncode("kava", bytes.Repeat([]byte{2}, 20))
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "vesting.csv")
+	content := "address,vesting_total_amounts\n" +
+		encode("cosmos", 20) + ",100000000\n" +
+		kava + ",100000000\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+	schedules := cmd.VestingSchedules{cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule()}
+	_, _, _, _, err = cmd.ParseVestingAccounts(filePath, bondDenom, time.Now(), schedules, nil)
+	require.Error(t, err)
+	_, _, vestingAccs, flagged, err := cmd.ParseVestingAccounts(filePath, bondDenom, time.Now(), schedules, policy)
+	require.NoError(t, err)
+	require.Len(t, vestingAccs, 2)
+	require.Len(t, flagged, 1)
+	require.Equal(t, 3, flagged[0].Row)
+	require.Equal(t, kava, flagged[0].Address)
+}
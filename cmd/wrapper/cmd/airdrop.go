@@ -0,0 +1,613 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+const (
+	flagRules     = "rules"
+	flagBreakdown = "breakdown"
+)
+
+// SnapshotRules is the rule file of an airdrop snapshot. The denom defaults to
+// the bond denom of the source chain. Weights are decimal strings of airdrop
+// amount per source unit and amounts are integer strings (underscores are
+// allowed as digit separators). Empty weights and bounds are not applied.
+type SnapshotRules struct {
+	Denom         string       `yaml:"denom" json:"denom"`
+	Balance       SnapshotRule `yaml:"balance" json:"balance"`
+	Delegation    SnapshotRule `yaml:"delegation" json:"delegation"`
+	LiquidityPool SnapshotRule `yaml:"liquidity_pool" json:"liquidity_pool"`
+	Total         string       `yaml:"total" json:"total"`     // airdrop amounts are scaled to add up to total
+	Cap           string       `yaml:"cap" json:"cap"`         // maximum airdrop amount of an address
+	Minimum       string       `yaml:"minimum" json:"minimum"` // airdrop amount below which an address is dropped
+	Exclude       struct {
+		Addresses  []string `yaml:"addresses" json:"addresses"`
+		Validators []string `yaml:"validators" json:"validators"` // delegations to these validators do not count
+	} `yaml:"exclude" json:"exclude"`
+}
+
+// SnapshotRule weighs one kind of holding of the source denom. Holdings below
+// the minimum do not count and holdings above the cap count as the cap.
+type SnapshotRule struct {
+	Weight  string `yaml:"weight" json:"weight"`
+	Minimum string `yaml:"minimum" json:"minimum"`
+	Cap     string `yaml:"cap" json:"cap"`
+}
+
+// SourceState is the part of an exported source chain state read by the
+// snapshot: accounts, balances, staking and gravity dex liquidity pools.
+type SourceState struct {
+	AppState struct {
+		Auth struct {
+			Accounts []struct {
+				Type        string `json:"@type"`
+				BaseAccount struct {
+					Address string `json:"address"`
+				} `json:"base_account"`
+			} `json:"accounts"`
+		} `json:"auth"`
+		Bank struct {
+			Balances []banktypes.Balance `json:"balances"`
+			Supply   sdk.Coins           `json:"supply"`
+		} `json:"bank"`
+		Staking struct {
+			Params struct {
+				BondDenom string `json:"bond_denom"`
+			} `json:"params"`
+			Validators []struct {
+				OperatorAddress string  `json:"operator_address"`
+				Tokens          sdk.Int `json:"tokens"`
+				DelegatorShares sdk.Dec `json:"delegator_shares"`
+			} `json:"validators"`
+			Delegations []struct {
+				DelegatorAddress string  `json:"delegator_address"`
+				ValidatorAddress string  `json:"validator_address"`
+				Shares           sdk.Dec `json:"shares"`
+			} `json:"delegations"`
+		} `json:"staking"`
+		Liquidity struct {
+			Pools []struct {
+				PoolCoinDenom         string `json:"pool_coin_denom"`
+				ReserveAccountAddress string `json:"reserve_account_address"`
+			} `json:"pools"`
+		} `json:"liquidity"`
+	} `json:"app_state"`
+}
+
+// SnapshotEntry is the holdings of the source denom and the airdrop amount of
+// an eligible address of the source chain.
+type SnapshotEntry struct {
+	Address       string  `json:"address"`
+	Balance       sdk.Int `json:"balance"`
+	Delegation    sdk.Int `json:"delegation"`
+	LiquidityPool sdk.Int `json:"liquidity_pool"`
+	Amount        sdk.Int `json:"amount"`
+}
+
+// SnapshotSummary counts what the rules did to the holders of the source denom.
+type SnapshotSummary struct {
+	Denom        string   `json:"denom"`
+	Holders      int      `json:"holders"`
+	Excluded     []string `json:"excluded"`
+	BelowMinimum int      `json:"below_minimum"`
+	Capped       int      `json:"capped"`
+	Eligible     int      `json:"eligible"`
+	Total        sdk.Int  `json:"total"`
+}
+
+// AirdropCmd groups the commands preparing airdrops.
+func AirdropCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "airdrop",
+		Short: "Airdrop subcommands",
+		RunE:  client.ValidateCmd,
+	}
+
+	cmd.AddCommand(AirdropSnapshotCmd(defaultNodeHome))
+
+	return cmd
+}
+
+func AirdropSnapshotCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot [exported-state]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Compute the airdrop result file from an exported source chain state",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Compute the airdrop result file from an exported source chain state.
+
+The exported state is the output of the export command of the source chain, for
+example gaiad export. For every address, the source denom is counted in its bank
+balance, in its staking delegations and in its share of the reserves of gravity
+dex liquidity pools. Module accounts and pool reserve accounts are always
+excluded. The rule file given by --%s weighs the holdings into airdrop amounts:
+
+  denom: uatom                  # defaults to the bond denom of the source chain
+  balance: { weight: "1", minimum: 1_000_000 }
+  delegation: { weight: "2", cap: 10_000_000_000 }
+  liquidity_pool: { weight: "3" }
+  total: 50_000_000_000_000     # scale the amounts to add up to total
+  cap: 100_000_000_000          # then cap the amount of an address
+  minimum: 1_000_000            # and drop addresses below the minimum
+  exclude:
+    addresses: [cosmos1...]
+    validators: [cosmosvaloper1...]
+
+Holdings below the minimum of a rule do not count and holdings above its cap
+count as the cap. The address,amount csv is sorted by address and written to
+--%s or stdout, with the holdings of every address if --%s is given. The
+SHA-256 of the exported state and of the rule file are printed with a summary so
+the result can be reproduced from public data.
+
+Example:
+$ %s airdrop snapshot cosmoshub-4-export.json --rules airdrop-rules.yaml --output-document result.csv
+`,
+				flagRules, flags.FlagOutputDocument, flagBreakdown,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			rulesPath, err := cmd.Flags().GetString(flagRules)
+			if err != nil {
+				return err
+			}
+			if rulesPath == "" {
+				return fmt.Errorf("--%s is required", flagRules)
+			}
+			outputDocument, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+			if err != nil {
+				return err
+			}
+			breakdown, err := cmd.Flags().GetBool(flagBreakdown)
+			if err != nil {
+				return err
+			}
+
+			rulesBz, err := os.ReadFile(rulesPath)
+			if err != nil {
+				return fmt.Errorf("failed to read rules: %w", err)
+			}
+			rules := &SnapshotRules{}
+			if err := yaml.UnmarshalStrict(rulesBz, rules); err != nil {
+				return fmt.Errorf("%s: failed to decode rules: %w", rulesPath, err)
+			}
+
+			stateBz, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read exported state: %w", err)
+			}
+			state := &SourceState{}
+			if err := json.Unmarshal(stateBz, state); err != nil {
+				return fmt.Errorf("failed to decode exported state %s: %w", args[0], err)
+			}
+
+			entries, summary, err := ComputeSnapshot(state, rules)
+			if err != nil {
+				return err
+			}
+
+			// The summary goes to stderr when the csv is written to stdout
+			out, summaryOut := cmd.OutOrStdout(), cmd.ErrOrStderr()
+			var buf bytes.Buffer
+			if outputDocument != "" {
+				out, summaryOut = &buf, cmd.OutOrStdout()
+			}
+			if err := WriteSnapshotCSV(out, entries, breakdown); err != nil {
+				return err
+			}
+			if outputDocument != "" {
+				if err := os.WriteFile(outputDocument, buf.Bytes(), 0644); err != nil {
+					return fmt.Errorf("failed to write airdrop result: %w", err)
+				}
+			}
+
+			stateHash, rulesHash := sha256.Sum256(stateBz), sha256.Sum256(rulesBz)
+			fmt.Fprintln(summaryOut, "ExportSHA256 :", hex.EncodeToString(stateHash[:]))
+			fmt.Fprintln(summaryOut, "RulesSHA256 :", hex.EncodeToString(rulesHash[:]))
+			fmt.Fprintln(summaryOut, "Denom :", summary.Denom)
+			fmt.Fprintln(summaryOut, "Holders :", summary.Holders)
+			fmt.Fprintln(summaryOut, "Excluded :", len(summary.Excluded))
+			fmt.Fprintln(summaryOut, "BelowMinimum :", summary.BelowMinimum)
+			fmt.Fprintln(summaryOut, "Capped :", summary.Capped)
+			fmt.Fprintln(summaryOut, "Eligible :", summary.Eligible)
+			fmt.Fprintln(summaryOut, "Total :", summary.Total)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagRules, "", "YAML or JSON rule file weighing the holdings of the source denom")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Write the airdrop result to the given file instead of stdout")
+	cmd.Flags().Bool(flagBreakdown, false, "Add the balance, delegation and liquidity pool holdings of every address")
+
+	return cmd
+}
+
+// ComputeSnapshot applies rules to the holdings of the source denom in state
+// and returns the eligible addresses sorted by address. The amount of an
+// address is the weighted sum of its holdings, scaled to the total, capped, and
+// dropped if below the minimum, in that order. Amounts are truncated.
+// Delegations only count if the denom is the bond denom of the source chain.
+func ComputeSnapshot(state *SourceState, rules *SnapshotRules) ([]SnapshotEntry, *SnapshotSummary, error) {
+	appState := &state.AppState
+
+	d := &profileDecoder{}
+	type weightedRule struct {
+		weight       *sdk.Dec
+		minimum, cap *sdk.Int
+	}
+	decodeRule := func(field string, r SnapshotRule) weightedRule {
+		return weightedRule{
+			weight:  d.optionalDec(field+".weight", r.Weight),
+			minimum: d.optionalInt(field+".minimum", r.Minimum),
+			cap:     d.optionalInt(field+".cap", r.Cap),
+		}
+	}
+	balanceRule := decodeRule("balance", rules.Balance)
+	delegationRule := decodeRule("delegation", rules.Delegation)
+	poolRule := decodeRule("liquidity_pool", rules.LiquidityPool)
+	total := d.optionalInt("total", rules.Total)
+	maxAmount := d.optionalInt("cap", rules.Cap)
+	minAmount := d.optionalInt("minimum", rules.Minimum)
+	if d.err != nil {
+		return nil, nil, d.err
+	}
+	if balanceRule.weight == nil && delegationRule.weight == nil && poolRule.weight == nil {
+		return nil, nil, fmt.Errorf("rules must weigh at least one of balance, delegation or liquidity_pool")
+	}
+
+	denom := rules.Denom
+	if denom == "" {
+		denom = appState.Staking.Params.BondDenom
+	}
+	if denom == "" {
+		return nil, nil, fmt.Errorf("rules have no denom and the exported state has no bond denom")
+	}
+
+	excluded := map[string]bool{}
+	for _, addr := range rules.Exclude.Addresses {
+		excluded[addr] = true
+	}
+	for _, acc := range appState.Auth.Accounts {
+		if strings.HasSuffix(acc.Type, ".ModuleAccount") {
+			excluded[acc.BaseAccount.Address] = true
+		}
+	}
+	for _, pool := range appState.Liquidity.Pools {
+		excluded[pool.ReserveAccountAddress] = true
+	}
+
+	entries := map[string]*SnapshotEntry{}
+	entry := func(addr string) *SnapshotEntry {
+		e, ok := entries[addr]
+		if !ok {
+			e = &SnapshotEntry{Address: addr, Balance: sdk.ZeroInt(), Delegation: sdk.ZeroInt(), LiquidityPool: sdk.ZeroInt(), Amount: sdk.ZeroInt()}
+			entries[addr] = e
+		}
+		return e
+	}
+
+	// The share of a pool coin in the denom reserve of its pool
+	reserves := map[string]sdk.Coins{}
+	for _, balance := range appState.Bank.Balances {
+		reserves[balance.Address] = balance.Coins
+	}
+	type poolShare struct {
+		reserve, supply sdk.Int
+	}
+	pools := map[string]poolShare{}
+	for _, pool := range appState.Liquidity.Pools {
+		share := poolShare{reserves[pool.ReserveAccountAddress].AmountOf(denom), appState.Bank.Supply.AmountOf(pool.PoolCoinDenom)}
+		if share.reserve.IsPositive() && share.supply.IsPositive() {
+			pools[pool.PoolCoinDenom] = share
+		}
+	}
+
+	for _, balance := range appState.Bank.Balances {
+		if amt := balance.Coins.AmountOf(denom); amt.IsPositive() {
+			e := entry(balance.Address)
+			e.Balance = e.Balance.Add(amt)
+		}
+		for _, coin := range balance.Coins {
+			share, ok := pools[coin.Denom]
+			if !ok || !coin.Amount.IsPositive() {
+				continue
+			}
+			e := entry(balance.Address)
+			e.LiquidityPool = e.LiquidityPool.Add(coin.Amount.Mul(share.reserve).Quo(share.supply))
+		}
+	}
+
+	if denom == appState.Staking.Params.BondDenom {
+		excludedValidators := map[string]bool{}
+		for _, valAddr := range rules.Exclude.Validators {
+			excludedValidators[valAddr] = true
+		}
+		type validatorShares struct {
+			tokens sdk.Int
+			shares sdk.Dec
+		}
+		validators := map[string]validatorShares{}
+		for _, val := range appState.Staking.Validators {
+			if !excludedValidators[val.OperatorAddress] && val.DelegatorShares.IsPositive() {
+				validators[val.OperatorAddress] = validatorShares{val.Tokens, val.DelegatorShares}
+			}
+		}
+		for _, del := range appState.Staking.Delegations {
+			val, ok := validators[del.ValidatorAddress]
+			if !ok {
+				continue
+			}
+			tokens := del.Shares.MulInt(val.tokens).Quo(val.shares).TruncateInt()
+			if tokens.IsPositive() {
+				e := entry(del.DelegatorAddress)
+				e.Delegation = e.Delegation.Add(tokens)
+			}
+		}
+	}
+
+	summary := &SnapshotSummary{Denom: denom, Holders: len(entries), Excluded: []string{}, Total: sdk.ZeroInt()}
+	weigh := func(r weightedRule, units sdk.Int) sdk.Dec {
+		if r.weight == nil || (r.minimum != nil && units.LT(*r.minimum)) {
+			return sdk.ZeroDec()
+		}
+		if r.cap != nil && units.GT(*r.cap) {
+			units = *r.cap
+		}
+		return r.weight.MulInt(units)
+	}
+
+	addrs := []string{}
+	for addr := range entries {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	scores := map[string]sdk.Dec{}
+	totalScore := sdk.ZeroDec()
+	for _, addr := range addrs {
+		if excluded[addr] {
+			summary.Excluded = append(summary.Excluded, addr)
+			continue
+		}
+		e := entries[addr]
+		score := weigh(balanceRule, e.Balance).Add(weigh(delegationRule, e.Delegation)).Add(weigh(poolRule, e.LiquidityPool))
+		if score.IsPositive() {
+			scores[addr] = score
+			totalScore = totalScore.Add(score)
+		}
+	}
+
+	result := []SnapshotEntry{}
+	for _, addr := range addrs {
+		score, ok := scores[addr]
+		if !ok {
+			continue
+		}
+		if total != nil {
+			score = score.MulInt(*total).Quo(totalScore)
+		}
+		e := entries[addr]
+		e.Amount = score.TruncateInt()
+		if maxAmount != nil && e.Amount.GT(*maxAmount) {
+			e.Amount = *maxAmount
+			summary.Capped++
+		}
+		if e.Amount.IsZero() || (minAmount != nil && e.Amount.LT(*minAmount)) {
+			summary.BelowMinimum++
+			continue
+		}
+		summary.Total = summary.Total.Add(e.Amount)
+		result = append(result, *e)
+	}
+	summary.Eligible = len(result)
+	return result, summary, nil
+}
+
+// WriteSnapshotCSV writes the address,amount airdrop result file read by
+// prepare-genesis, followed by the holdings of every address if breakdown.
+func WriteSnapshotCSV(w io.Writer, entries []SnapshotEntry, breakdown bool) error {
+	header := []string{"address", "amount"}
+	if breakdown {
+		header = append(header, "balance", "delegation", "liquidity_pool")
+	}
+	records := [][]string{header}
+	for _, e := range entries {
+		record := []string{e.Address, e.Amount.String()}
+		if breakdown {
+			record = append(record, e.Balance.String(), e.Delegation.String(), e.LiquidityPool.String())
+		}
+		records = append(records, record)
+	}
+	return csv.NewWriter(w).WriteAll(records)
+}
+
+// Rules of an airdrop policy for rows below the minimum and the excess of rows
+// above the cap.
+const (
+	AirdropBelowMinimumDrop    = "drop"
+	AirdropBelowMinimumRoundUp = "round-up"
+	AirdropExcessBurn          = "burn"
+	AirdropExcessRedistribute  = "redistribute"
+)
+
+// Rules reported in airdrop adjustments.
+const (
+	AirdropRuleExcluded      = "excluded"
+	AirdropRuleBelowMinimum  = "below-minimum"
+	AirdropRuleRoundedUp     = "rounded-up"
+	AirdropRuleCapped        = "capped"
+	AirdropRuleRedistributed = "redistributed"
+	AirdropRuleFlagged       = "flagged" // address converted although its owner may not control it
+)
+
+// AirdropPolicy adjusts the rows of the airdrop result file before the claim
+// records are created. Bounds are not applied if nil.
+type AirdropPolicy struct {
+	Exclude      map[string]string // reason by address with the account address prefix
+	Minimum      *sdk.Int
+	RoundUp      bool // raise rows below the minimum instead of dropping them
+	Cap          *sdk.Int
+	Redistribute bool // share the excess of capped rows instead of burning it
+}
+
+// AirdropRow is a recipient of the airdrop result file.
+type AirdropRow struct {
+	Row     int    // line of the row in the file
+	Address string // with the account address prefix
+	Amount  sdk.Int
+}
+
+// AirdropAdjustment is a row changed by a rule of the airdrop policy.
+type AirdropAdjustment struct {
+	Row            int     `json:"row"`
+	Address        string  `json:"address"`
+	Rule           string  `json:"rule"`
+	Reason         string  `json:"reason,omitempty"`
+	Amount         sdk.Int `json:"amount"`
+	AdjustedAmount sdk.Int `json:"adjusted_amount"`
+}
+
+// Apply drops the excluded rows, then drops or rounds up the rows below the
+// minimum, then caps the rows above the cap. The excess is shared by the rows
+// below the cap pro rata to their amount, without raising any above the cap, or
+// burned. Every change is returned as an adjustment, exclusions and minimums in
+// row order before caps and redistributions, along with the rows left and the
+// burned amount.
+func (p *AirdropPolicy) Apply(rows []AirdropRow) ([]AirdropRow, []AirdropAdjustment, sdk.Int) {
+	adjustments := []AirdropAdjustment{}
+	adjust := func(row AirdropRow, rule, reason string, amount sdk.Int) {
+		adjustments = append(adjustments, AirdropAdjustment{row.Row, row.Address, rule, reason, row.Amount, amount})
+	}
+
+	kept := []AirdropRow{}
+	for _, row := range rows {
+		if reason, ok := p.Exclude[row.Address]; ok {
+			adjust(row, AirdropRuleExcluded, reason, sdk.ZeroInt())
+			continue
+		}
+		if p.Minimum != nil && row.Amount.LT(*p.Minimum) {
+			if !p.RoundUp {
+				adjust(row, AirdropRuleBelowMinimum, "", sdk.ZeroInt())
+				continue
+			}
+			adjust(row, AirdropRuleRoundedUp, "", *p.Minimum)
+			row.Amount = *p.Minimum
+		}
+		kept = append(kept, row)
+	}
+
+	excess := sdk.ZeroInt()
+	if p.Cap == nil {
+		return kept, adjustments, excess
+	}
+	for i, row := range kept {
+		if row.Amount.GT(*p.Cap) {
+			adjust(row, AirdropRuleCapped, "", *p.Cap)
+			excess = excess.Add(row.Amount.Sub(*p.Cap))
+			kept[i].Amount = *p.Cap
+		}
+	}
+	if !p.Redistribute {
+		return kept, adjustments, excess
+	}
+
+	capped := make([]sdk.Int, len(kept))
+	for i, row := range kept {
+		capped[i] = row.Amount
+	}
+	for excess.IsPositive() {
+		base := sdk.ZeroInt()
+		for _, row := range kept {
+			if row.Amount.LT(*p.Cap) {
+				base = base.Add(row.Amount)
+			}
+		}
+		if base.IsZero() {
+			break
+		}
+		distributed := sdk.ZeroInt()
+		for i, row := range kept {
+			if !row.Amount.LT(*p.Cap) {
+				continue
+			}
+			share := sdk.MinInt(excess.Mul(row.Amount).Quo(base), p.Cap.Sub(row.Amount))
+			kept[i].Amount = row.Amount.Add(share)
+			distributed = distributed.Add(share)
+		}
+		// The truncated dust goes to the rows below the cap, one unit each
+		if distributed.IsZero() {
+			for i := range kept {
+				if distributed.Equal(excess) {
+					break
+				}
+				if kept[i].Amount.LT(*p.Cap) {
+					kept[i].Amount = kept[i].Amount.AddRaw(1)
+					distributed = distributed.AddRaw(1)
+				}
+			}
+		}
+		excess = excess.Sub(distributed)
+	}
+	for i, row := range kept {
+		if !row.Amount.Equal(capped[i]) {
+			adjustments = append(adjustments, AirdropAdjustment{row.Row, row.Address, AirdropRuleRedistributed, "", capped[i], row.Amount})
+		}
+	}
+	return kept, adjustments, excess
+}
+
+// AirdropResult is an airdrop as set in genesis. Its supply is the genesis
+// balances of the recipients and the claimable coins and reserve funded on the
+// source account.
+type AirdropResult struct {
+	Name          string
+	Id            uint64
+	SourceAddress string
+	Recipients    int
+	Genesis       sdk.Coin
+	Claimable     sdk.Coin
+	Reserve       sdk.Coin    // funded on the source account but not claimable
+	Burned        sdk.Coin    // excess of capped rows, not minted
+	Dust          sdk.DecCoin // fractions of the genesis balances rounded down, claimable instead
+	Adjustments   []AirdropAdjustment
+}
+
+// Supply returns the amount minted for the airdrop.
+func (r AirdropResult) Supply() sdk.Coin {
+	return r.Genesis.Add(r.Claimable).Add(r.Reserve)
+}
+
+// WriteAirdropReport writes the adjustments of the airdrop policies as csv.
+func WriteAirdropReport(w io.Writer, airdrops []AirdropResult) error {
+	records := [][]string{{"airdrop_id", "row", "address", "rule", "reason", "amount", "adjusted_amount"}}
+	for _, airdrop := range airdrops {
+		for _, a := range airdrop.Adjustments {
+			records = append(records, []string{
+				fmt.Sprint(airdrop.Id), fmt.Sprint(a.Row), a.Address, a.Rule, a.Reason, a.Amount.String(), a.AdjustedAmount.String(),
+			})
+		}
+	}
+	return csv.NewWriter(w).WriteAll(records)
+}
@@ -0,0 +1,101 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestComputeSnapshot(t *testing.T) {
+	exported := `{"app_state": {
+		"auth": {"accounts": [{"@type": "/cosmos.auth.v1beta1.ModuleAccount", "base_account": {"address": "module"}}]},
+		"bank": {
+			"balances": [
+				{"address": "module", "coins": [{"denom": "uatom", "amount": "3000"}]},
+				{"address": "alice", "coins": [{"denom": "pool1", "amount": "50"}, {"denom": "uatom", "amount": "1000"}]},
+				{"address": "bob", "coins": [{"denom": "uatom", "amount": "10"}]},
+				{"address": "carol", "coins": [{"denom": "uatom", "amount": "5000"}]},
+				{"address": "reserve", "coins": [{"denom": "uatom", "amount": "400"}]}
+			],
+			"supply": [{"denom": "pool1", "amount": "100"}, {"denom": "uatom", "amount": "9410"}]
+		},
+		"staking": {
+			"params": {"bond_denom": "uatom"},
+			"validators": [{"operator_address": "val", "tokens": "3000", "delegator_shares": "1500"}],
+			"delegations": [{"delegator_address": "bob", "validator_address": "val", "shares": "1500"}]
+		},
+		"liquidity": {"pools": [{"pool_coin_denom": "pool1", "reserve_account_address": "reserve"}]}
+	}}`
+	state := &cmd.SourceState{}
+	require.NoError(t, json.Unmarshal([]byte(exported), state))
+
+	rules := &cmd.SnapshotRules{
+		Balance:       cmd.SnapshotRule{Weight: "1", Minimum: "100"},
+		Delegation:    cmd.SnapshotRule{Weight: "2"},
+		LiquidityPool: cmd.SnapshotRule{Weight: "3"},
+		Cap:           "5000",
+	}
+	rules.Exclude.Addresses = []string{"carol"}
+	entries, summary, err := cmd.ComputeSnapshot(state, rules)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "alice", entries[0].Address)
+	require.Equal(t, sdk.NewInt(200), entries[0].LiquidityPool)
+	require.Equal(t, sdk.NewInt(1600), entries[0].Amount)
+	require.Equal(t, "bob", entries[1].Address)
+	require.Equal(t, sdk.NewInt(3000), entries[1].Delegation)
+	require.Equal(t, sdk.NewInt(5000), entries[1].Amount)
+	require.Equal(t, []string{"carol", "module", "reserve"}, summary.Excluded)
+	require.Equal(t, 1, summary.Capped)
+
+	rules.Cap, rules.Total, rules.Minimum = "", "1000", "300"
+	entries, summary, err = cmd.ComputeSnapshot(state, rules)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, sdk.NewInt(789), entries[0].Amount)
+	require.Equal(t, 1, summary.BelowMinimum)
+}
+
+func TestAirdropPolicy(t *testing.T) {
+	rows := []cmd.AirdropRow{
+		{Row: 2, Address: "a", Amount: sdk.NewInt(500)},
+		{Row: 3, Address: "b", Amount: sdk.NewInt(10)},
+		{Row: 4, Address: "c", Amount: sdk.NewInt(100)},
+		{Row: 5, Address: "d", Amount: sdk.NewInt(200)},
+		{Row: 6, Address: "e", Amount: sdk.NewInt(1000)},
+	}
+	minimum, cap := sdk.NewInt(50), sdk.NewInt(400)
+	policy := &cmd.AirdropPolicy{Exclude: map[string]string{"e": "exchange"}, Minimum: &minimum, Cap: &cap}
+
+	kept, adjustments, burned := policy.Apply(rows)
+	require.Len(t, kept, 3)
+	require.Equal(t, sdk.NewInt(100), burned)
+	require.Equal(t, []string{cmd.AirdropRuleBelowMinimum, cmd.AirdropRuleExcluded, cmd.AirdropRuleCapped},
+		[]string{adjustments[0].Rule, adjustments[1].Rule, adjustments[2].Rule})
+	require.Equal(t, "exchange", adjustments[1].Reason)
+	require.Equal(t, 6, adjustments[1].Row)
+
+	// The excess of a goes to c and d pro rata, and the excess of d to c
+	policy.RoundUp, policy.Redistribute = true, true
+	cap = sdk.NewInt(250)
+	kept, adjustments, burned = policy.Apply(rows)
+	require.True(t, burned.IsZero())
+	total := sdk.ZeroInt()
+	for _, row := range kept {
+		require.True(t, row.Amount.LTE(cap))
+		total = total.Add(row.Amount)
+	}
+	require.Equal(t, sdk.NewInt(850), total)
+	require.Equal(t, cmd.AirdropRuleRoundedUp, adjustments[0].Rule)
+	require.Equal(t, sdk.NewInt(50), adjustments[0].AdjustedAmount)
+	require.Equal(t, cmd.AirdropRuleRedistributed, adjustments[len(adjustments)-1].Rule)
+
+	// Nothing is left below the cap to share the excess with
+	cap = sdk.NewInt(50)
+	_, _, burned = policy.Apply(rows)
+	require.Equal(t, sdk.NewInt(650), burned)
+}
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+)
+
+const flagNetwork = "network"
+
+// Allocation categories of the audit report. An address falls in the first
+// matching category in this order, except for the amount locked in a vesting
+// account which is always reported as vesting.
+const (
+	CategoryModule      = "module"
+	CategoryClaimLocked = "claim-locked"
+	CategoryFoundation  = "foundation"
+	CategoryValidators  = "validators"
+	CategoryAirdrop     = "airdrop"
+	CategoryVesting     = "vesting"
+	CategoryOther       = "other"
+)
+
+var auditCategories = []string{
+	CategoryAirdrop, CategoryClaimLocked, CategoryFoundation, CategoryVesting,
+	CategoryValidators, CategoryModule, CategoryOther,
+}
+
+// AuditLabels holds addresses that cannot be recognized from the genesis file alone.
+type AuditLabels struct {
+	FoundationAddresses []string
+	ValidatorAddresses  []string
+}
+
+// Allocation is the amount held by the accounts of a category.
+type Allocation struct {
+	Category string    `json:"category"`
+	Accounts int       `json:"accounts"`
+	Coins    sdk.Coins `json:"coins"`
+	Share    sdk.Dec   `json:"share"` // of the bond denom supply
+}
+
+// AuditReport is the result of auditing the accounts and supply of a genesis file.
+type AuditReport struct {
+	BondDenom   string       `json:"bond_denom"`
+	Supply      sdk.Coins    `json:"supply"`
+	Balances    sdk.Coins    `json:"balances"`
+	Allocations []Allocation `json:"allocations"`
+	Issues      []string     `json:"issues"`
+}
+
+func AuditCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [genesis-file]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Audit the supply and accounts of a genesis file",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Audit the supply and accounts of a genesis file.
+
+The following is checked:
+  - the bank balances add up to the supply for every denom
+  - every balance has a matching auth account
+  - the original vesting of a vesting account does not exceed its balance
+
+Balances are reported per allocation category. Airdrop recipients, claim source
+accounts, vesting and module accounts and gentx delegators are recognized from the
+genesis file. The foundation and validator accounts of a builtin network or a
+network profile are recognized with --network or --profile.
+
+The genesis file defaults to the one in --home.
+
+Example:
+$ %s audit --network mainnet
+$ %s audit ./genesis.json --profile ./testnet.yaml --output json
+`,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genFile := serverCtx.Config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+
+			network, err := cmd.Flags().GetString(flagNetwork)
+			if err != nil {
+				return err
+			}
+			profilePath, err := cmd.Flags().GetString(flagProfile)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+
+			var profile *Profile
+			switch {
+			case network != "" && profilePath != "":
+				return fmt.Errorf("--%s cannot be used together with --%s", flagNetwork, flagProfile)
+			case network != "":
+				profile, err = parseNetworkType(network)
+			case profilePath != "":
+				profile, err = LoadProfile(profilePath)
+			}
+			if err != nil {
+				return err
+			}
+
+			labels := AuditLabels{}
+			if profile != nil {
+				labels.FoundationAddresses = append(labels.FoundationAddresses, profile.Foundation.Address)
+				for _, balance := range profile.ValidatorBalances {
+					labels.ValidatorAddresses = append(labels.ValidatorAddresses, balance.Address)
+				}
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis doc from file: %w", err)
+			}
+			var appState map[string]json.RawMessage
+			if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			report, err := AuditGenesis(clientCtx, appState, labels)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				bz, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal audit report: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			case "text":
+				if err := report.WriteText(cmd.OutOrStdout()); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown output format %q, expected text or json", output)
+			}
+
+			if len(report.Issues) > 0 {
+				return fmt.Errorf("audit found %d issues", len(report.Issues))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagNetwork, "", "Builtin network type whose foundation and validator accounts are labeled (mainnet|testnet)")
+	cmd.Flags().String(flagProfile, "", "Network profile whose foundation and validator accounts are labeled")
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "text", "Output format (text|json)")
+
+	return cmd
+}
+
+// AuditGenesis checks the supply, balances and accounts of appState and
+// returns the report with the balances allocated to categories.
+func AuditGenesis(clientCtx client.Context, appState map[string]json.RawMessage, labels AuditLabels) (*AuditReport, error) {
+	cdc := clientCtx.Codec
+
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+
+	var stakingGenState stakingtypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[stakingtypes.ModuleName], &stakingGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal staking genesis state: %w", err)
+	}
+	var claimGenState claimtypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[claimtypes.ModuleName], &claimGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claim genesis state: %w", err)
+	}
+
+	report := &AuditReport{
+		BondDenom: stakingGenState.Params.BondDenom,
+		Supply:    bankGenState.Supply,
+		Balances:  sdk.Coins{},
+		Issues:    []string{},
+	}
+
+	// Categorize the addresses
+	categories := map[string]string{}
+	setCategory := func(addr, category string) {
+		if _, ok := categories[addr]; !ok {
+			categories[addr] = category
+		}
+	}
+	accMap := map[string]authtypes.GenesisAccount{}
+	for _, acc := range accs {
+		accMap[acc.GetAddress().String()] = acc
+		if _, ok := acc.(authtypes.ModuleAccountI); ok {
+			setCategory(acc.GetAddress().String(), CategoryModule)
+		}
+	}
+	for _, airdrop := range claimGenState.Airdrops {
+		setCategory(airdrop.SourceAddress, CategoryClaimLocked)
+	}
+	for _, addr := range labels.FoundationAddresses {
+		setCategory(addr, CategoryFoundation)
+	}
+	for _, addr := range labels.ValidatorAddresses {
+		setCategory(addr, CategoryValidators)
+	}
+	genTxState := genutiltypes.GetGenesisStateFromAppState(cdc, appState)
+	for i, genTx := range genTxState.GenTxs {
+		tx, err := clientCtx.TxConfig.TxJSONDecoder()(genTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gentx %d: %w", i, err)
+		}
+		for _, msg := range tx.GetMsgs() {
+			if msg, ok := msg.(*stakingtypes.MsgCreateValidator); ok {
+				setCategory(msg.DelegatorAddress, CategoryValidators)
+			}
+		}
+	}
+	for _, record := range claimGenState.ClaimRecords {
+		setCategory(record.Recipient, CategoryAirdrop)
+	}
+
+	// Allocate the balances and check them against the accounts
+	allocations := map[string]*Allocation{}
+	for _, category := range auditCategories {
+		allocations[category] = &Allocation{Category: category, Coins: sdk.Coins{}}
+	}
+	allocate := func(category string, coins sdk.Coins) {
+		allocations[category].Accounts++
+		allocations[category].Coins = allocations[category].Coins.Add(coins...)
+	}
+
+	seen := map[string]bool{}
+	for _, balance := range bankGenState.Balances {
+		if seen[balance.Address] {
+			report.Issues = append(report.Issues, fmt.Sprintf("%s: duplicate balance", balance.Address))
+		}
+		seen[balance.Address] = true
+		report.Balances = report.Balances.Add(balance.Coins...)
+
+		category, ok := categories[balance.Address]
+		if !ok {
+			category = CategoryOther
+		}
+
+		acc, ok := accMap[balance.Address]
+		if !ok {
+			report.Issues = append(report.Issues, fmt.Sprintf("%s: balance %s has no auth account", balance.Address, balance.Coins))
+			allocate(category, balance.Coins)
+			continue
+		}
+
+		vacc, ok := acc.(vestexported.VestingAccount)
+		if !ok {
+			allocate(category, balance.Coins)
+			continue
+		}
+
+		originalVesting := vacc.GetOriginalVesting()
+		if !originalVesting.IsAllLTE(balance.Coins) {
+			report.Issues = append(report.Issues, fmt.Sprintf("%s: original vesting %s exceeds balance %s",
+				balance.Address, originalVesting, balance.Coins))
+			allocate(CategoryVesting, balance.Coins)
+			continue
+		}
+		allocate(CategoryVesting, originalVesting)
+		if rest := balance.Coins.Sub(originalVesting); !rest.IsZero() {
+			allocate(category, rest)
+		}
+	}
+
+	// Accounts without a balance, in the order of the accounts
+	for _, acc := range accs {
+		addr := acc.GetAddress().String()
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		if vacc, ok := acc.(vestexported.VestingAccount); ok && !vacc.GetOriginalVesting().IsZero() {
+			report.Issues = append(report.Issues, fmt.Sprintf("%s: original vesting %s exceeds balance, which is missing",
+				addr, vacc.GetOriginalVesting()))
+			continue
+		}
+		report.Issues = append(report.Issues, fmt.Sprintf("%s: account has no balance", addr))
+	}
+
+	if report.Supply.Empty() {
+		report.Issues = append(report.Issues, "supply is not set")
+	} else {
+		for _, denom := range unionDenoms(report.Supply, report.Balances) {
+			supply, balances := report.Supply.AmountOf(denom), report.Balances.AmountOf(denom)
+			if !supply.Equal(balances) {
+				report.Issues = append(report.Issues, fmt.Sprintf("%s: supply %s does not match the sum of balances %s",
+					denom, supply, balances))
+			}
+		}
+	}
+
+	bondSupply := report.Balances.AmountOf(report.BondDenom)
+	for _, category := range auditCategories {
+		allocation := allocations[category]
+		allocation.Share = sdk.ZeroDec()
+		if bondSupply.IsPositive() {
+			allocation.Share = allocation.Coins.AmountOf(report.BondDenom).ToDec().QuoInt(bondSupply)
+		}
+		report.Allocations = append(report.Allocations, *allocation)
+	}
+
+	return report, nil
+}
+
+// WriteText writes the allocation table and the issues of the report to w.
+func (r *AuditReport) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CATEGORY\tACCOUNTS\tCOINS\tSHARE")
+	for _, allocation := range r.Allocations {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", allocation.Category, allocation.Accounts,
+			allocation.Coins, formatPercent(allocation.Share))
+	}
+	fmt.Fprintf(tw, "total\t\t%s\t\n", r.Balances)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Supply :", r.Supply)
+	if len(r.Issues) == 0 {
+		fmt.Fprintln(w, "No issues found")
+	}
+	for _, issue := range r.Issues {
+		fmt.Fprintln(w, "issue:", issue)
+	}
+	return nil
+}
+
+// formatPercent formats a ratio as a percentage with two decimals.
+func formatPercent(d sdk.Dec) string {
+	basisPoints := d.MulInt64(10000).TruncateInt64()
+	return fmt.Sprintf("%d.%02d%%", basisPoints/100, basisPoints%100)
+}
+
+func unionDenoms(a, b sdk.Coins) []string {
+	denoms := []string{}
+	for _, coin := range a.Add(b...) {
+		denoms = append(denoms, coin.Denom)
+	}
+	return denoms
+}
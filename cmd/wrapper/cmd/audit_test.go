@@ -0,0 +1,69 @@
+package cmd_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestAuditGenesis(t *testing.T) {
+	clientCtx, appState, _, genStates := prepareTestGenesis(t)
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+
+	labels := cmd.AuditLabels{FoundationAddresses: []string{profile.Foundation.Address}}
+	report, err := cmd.AuditGenesis(clientCtx, appState, labels)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+	for _, allocation := range report.Allocations {
+		if allocation.Category == cmd.CategoryFoundation {
+			require.Equal(t, genStates.FoundationSupply, allocation.Coins[0])
+		}
+	}
+
+	// A balance without an account also breaks the supply
+	bankGenState := banktypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	bankGenState.Balances = append(bankGenState.Balances, banktypes.Balance{
+		Address: sdk.AccAddress(make([]byte, 20)).String(),
+		Coins:   sdk.NewCoins(sdk.NewInt64Coin("utcre", 1)),
+	})
+	appState[banktypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(bankGenState)
+	report, err = cmd.AuditGenesis(clientCtx, appState, labels)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 2)
+
+	// A vesting account without a balance vests more than it holds
+	authGenState := authtypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	require.NoError(t, err)
+	vestingAddr := sdk.AccAddress(make([]byte, 20))
+	vestingAddr[0] = 1
+	baseAcc := authtypes.NewBaseAccount(vestingAddr, nil, 0, 0)
+	accs = append(accs, authvesting.NewDelayedVestingAccount(baseAcc, sdk.NewCoins(sdk.NewInt64Coin("utcre", 1)), 1))
+	authGenState.Accounts, err = authtypes.PackAccounts(accs)
+	require.NoError(t, err)
+	appState[authtypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(&authGenState)
+	report, err = cmd.AuditGenesis(clientCtx, appState, labels)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 3)
+	require.Contains(t, report.Issues, vestingAddr.String()+": original vesting 1utcre exceeds balance, which is missing")
+
+	// A supply in another denom than the balances is reported per denom
+	_, appState, _, _ = prepareTestGenesis(t)
+	bankGenState = banktypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	supply := bankGenState.Supply.AmountOf("utcre")
+	bankGenState.Supply = sdk.NewCoins(sdk.NewCoin("uatom", supply))
+	appState[banktypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(bankGenState)
+	report, err = cmd.AuditGenesis(clientCtx, appState, labels)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"uatom: supply " + supply.String() + " does not match the sum of balances 0",
+		"utcre: supply 0 does not match the sum of balances " + supply.String(),
+	}, report.Issues)
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const (
+	flagCanonical = "canonical"
+	flagSHA256    = "sha256"
+)
+
+// GenesisHashes are the SHA-256 hashes of a genesis file.
+type GenesisHashes struct {
+	File     string `json:"file"`      // hex SHA-256 of the file bytes
+	AppState string `json:"app_state"` // hex SHA-256 of the compact canonical app_state
+}
+
+// CanonicalJSON re-encodes bz with the keys of every object sorted, two space
+// indentation and no HTML escaping. Numbers are kept as written. With an empty
+// indent the output is compact.
+func CanonicalJSON(bz []byte, indent string) ([]byte, error) {
+	v, err := decodeJSON(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode json: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode json: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// ExportCanonicalGenesisFile validates genDoc and writes it to genFile as canonical
+// JSON with a trailing newline. Writing the same genesis twice gives the same bytes.
+func ExportCanonicalGenesisFile(genDoc *tmtypes.GenesisDoc, genFile string) (*GenesisHashes, error) {
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	bz, err := tmjson.Marshal(genDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis doc: %w", err)
+	}
+	bz, err = CanonicalJSON(bz, "  ")
+	if err != nil {
+		return nil, err
+	}
+	bz = append(bz, '\n')
+
+	if err := tmos.WriteFile(genFile, bz, 0644); err != nil {
+		return nil, err
+	}
+	return HashGenesis(bz)
+}
+
+// HashGenesis returns the hashes of the genesis file content bz.
+func HashGenesis(bz []byte) (*GenesisHashes, error) {
+	var genDoc struct {
+		AppState json.RawMessage `json:"app_state"`
+	}
+	if err := json.Unmarshal(bz, &genDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis doc: %w", err)
+	}
+	appState, err := CanonicalJSON(genDoc.AppState, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid app_state: %w", err)
+	}
+
+	fileHash := sha256.Sum256(bz)
+	appStateHash := sha256.Sum256(appState)
+	return &GenesisHashes{
+		File:     hex.EncodeToString(fileHash[:]),
+		AppState: hex.EncodeToString(appStateHash[:]),
+	}, nil
+}
+
+// CanonicalGenesisHash returns the SHA-256 of the canonical form of the genesis
+// file content bz. It is the file hash of a genesis written with --canonical.
+func CanonicalGenesisHash(bz []byte) ([]byte, error) {
+	canonical, err := CanonicalJSON(bz, "  ")
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(append(canonical, '\n'))
+	return hash[:], nil
+}
+
+func VerifyGenesisCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-genesis [genesis-file]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Verify that a genesis file matches a published SHA-256 hash",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Verify that a genesis file matches a published SHA-256 hash.
+
+The hash is compared with the SHA-256 of the file bytes, so the file must be
+exactly the one that was published. The hash of the canonical app_state is
+printed as well; it stays the same when only the formatting of the file differs.
+
+The genesis file defaults to the one in --home.
+
+Example:
+$ %s verify-genesis --sha256 3b1f...e9
+$ %s verify-genesis ./genesis.json --sha256 3b1f...e9
+`,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genFile := serverCtx.Config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+
+			expected, err := cmd.Flags().GetString(flagSHA256)
+			if err != nil {
+				return err
+			}
+			if expected == "" {
+				return fmt.Errorf("--%s is required", flagSHA256)
+			}
+
+			bz, err := os.ReadFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis file: %w", err)
+			}
+			if _, err := tmtypes.GenesisDocFromJSON(bz); err != nil {
+				return fmt.Errorf("invalid genesis file: %w", err)
+			}
+			hashes, err := HashGenesis(bz)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "FileSHA256 :", hashes.File)
+			fmt.Fprintln(out, "AppStateSHA256 :", hashes.AppState)
+
+			if !strings.EqualFold(strings.TrimSpace(expected), hashes.File) {
+				return fmt.Errorf("genesis file %s does not match, expected SHA-256 %s", genFile, expected)
+			}
+			fmt.Fprintln(out, "Genesis file matches")
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagSHA256, "", "Published hex SHA-256 of the genesis file")
+
+	return cmd
+}
@@ -0,0 +1,46 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestExportCanonicalGenesisFile(t *testing.T) {
+	appState := json.RawMessage(`{"bank":{"supply":[],"balances":[],"params":{"send_enabled":[],"default_send_enabled":true}},"auth":{"accounts":[]}}`)
+	genDoc := &tmtypes.GenesisDoc{
+		GenesisTime: time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC),
+		ChainID:     "crescent-1",
+		AppState:    appState,
+	}
+
+	dir := t.TempDir()
+	first, err := cmd.ExportCanonicalGenesisFile(genDoc, filepath.Join(dir, "a.json"))
+	require.NoError(t, err)
+	second, err := cmd.ExportCanonicalGenesisFile(genDoc, filepath.Join(dir, "b.json"))
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	bz, err := os.ReadFile(filepath.Join(dir, "a.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "\"app_state\": {\n    \"auth\": {")
+	_, err = tmtypes.GenesisDocFromJSON(bz)
+	require.NoError(t, err)
+
+	// Reformatting the file changes its hash but not the hash of the app state.
+	var v interface{}
+	require.NoError(t, json.Unmarshal(bz, &v))
+	compact, err := json.Marshal(v)
+	require.NoError(t, err)
+	hashes, err := cmd.HashGenesis(compact)
+	require.NoError(t, err)
+	require.NotEqual(t, first.File, hashes.File)
+	require.Equal(t, first.AppState, hashes.AppState)
+}
@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	budgettypes "github.com/tendermint/budget/x/budget/types"
+
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+	farmingtypes "github.com/crescent-network/crescent/x/farming/types"
+	liquiditytypes "github.com/crescent-network/crescent/x/liquidity/types"
+	liquidstakingtypes "github.com/crescent-network/crescent/x/liquidstaking/types"
+	minttypes "github.com/crescent-network/crescent/x/mint/types"
+)
+
+// Kinds of a GenesisChange.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// GenesisChange is a semantic difference between two genesis files.
+// From and To are compact JSON values, or coin deltas for balances and supply.
+type GenesisChange struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+func (c GenesisChange) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, c.To)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, c.From)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, c.From, c.To)
+	}
+}
+
+// genesisStateTypes are the module genesis states that are decoded with the codec
+// before they are compared, so that differences in field order, omitted defaults
+// and Any encoding do not show up as changes.
+var genesisStateTypes = map[string]func() proto.Message{
+	authtypes.ModuleName:          func() proto.Message { return &authtypes.GenesisState{} },
+	banktypes.ModuleName:          func() proto.Message { return &banktypes.GenesisState{} },
+	budgettypes.ModuleName:        func() proto.Message { return &budgettypes.GenesisState{} },
+	claimtypes.ModuleName:         func() proto.Message { return &claimtypes.GenesisState{} },
+	crisistypes.ModuleName:        func() proto.Message { return &crisistypes.GenesisState{} },
+	distrtypes.ModuleName:         func() proto.Message { return &distrtypes.GenesisState{} },
+	farmingtypes.ModuleName:       func() proto.Message { return &farmingtypes.GenesisState{} },
+	genutiltypes.ModuleName:       func() proto.Message { return &genutiltypes.GenesisState{} },
+	govtypes.ModuleName:           func() proto.Message { return &govtypes.GenesisState{} },
+	liquiditytypes.ModuleName:     func() proto.Message { return &liquiditytypes.GenesisState{} },
+	liquidstakingtypes.ModuleName: func() proto.Message { return &liquidstakingtypes.GenesisState{} },
+	minttypes.ModuleName:          func() proto.Message { return &minttypes.GenesisState{} },
+	slashingtypes.ModuleName:      func() proto.Message { return &slashingtypes.GenesisState{} },
+	stakingtypes.ModuleName:       func() proto.Message { return &stakingtypes.GenesisState{} },
+}
+
+// keyedLists are lists compared by the key of their elements instead of by index.
+var keyedLists = map[string]func(interface{}) string{
+	"auth.accounts":         func(v interface{}) string { return jsonField(v, "address") },
+	"budget.params.budgets": func(v interface{}) string { return jsonField(v, "name") },
+	"claim.airdrops":        func(v interface{}) string { return jsonField(v, "id") },
+	"claim.claim_records": func(v interface{}) string {
+		return jsonField(v, "airdrop_id") + "/" + jsonField(v, "recipient")
+	},
+	"staking.validators": func(v interface{}) string { return jsonField(v, "operator_address") },
+	"staking.delegations": func(v interface{}) string {
+		return jsonField(v, "delegator_address") + "/" + jsonField(v, "validator_address")
+	},
+	"liquidstaking.params.whitelisted_validators": func(v interface{}) string {
+		return jsonField(v, "validator_address")
+	},
+}
+
+// GenesisCmd groups the commands working on a finished genesis file.
+func GenesisCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "genesis",
+		Short: "Genesis file subcommands",
+		RunE:  client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		GenesisDiffCmd(),
+		GenesisSignCmd(defaultNodeHome),
+		GenesisVerifySignaturesCmd(defaultNodeHome),
+	)
+
+	return cmd
+}
+
+func GenesisDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [a.json] [b.json]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Show the semantic differences between two genesis files",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Show the semantic differences between two genesis files.
+
+Module states are decoded with the codec, so the order of fields and the
+encoding of Any values do not matter. Accounts, claim records, airdrops, budgets,
+validators, delegations and whitelisted validators are matched by their key, and
+bank balances and supply are reported as coin deltas per address.
+
+Example:
+$ %s genesis diff rc1/genesis.json rc2/genesis.json
+$ %s genesis diff rc1/genesis.json rc2/genesis.json --output json
+`,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+
+			genDocs := make([]*tmtypes.GenesisDoc, 2)
+			for i, genFile := range args {
+				genDocs[i], err = tmtypes.GenesisDocFromFile(genFile)
+				if err != nil {
+					return fmt.Errorf("failed to read genesis doc from file %s: %w", genFile, err)
+				}
+			}
+
+			changes, err := DiffGenesis(clientCtx.Codec, genDocs[0], genDocs[1])
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				return writeJSON(cmd.OutOrStdout(), changes)
+			case "text":
+				return writeGenesisChanges(cmd.OutOrStdout(), changes)
+			default:
+				return fmt.Errorf("unknown output format %q, expected text or json", output)
+			}
+		},
+	}
+
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "text", "Output format (text|json)")
+
+	return cmd
+}
+
+func writeGenesisChanges(w io.Writer, changes []GenesisChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No differences")
+		return err
+	}
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffGenesis returns the semantic changes from genesis a to genesis b.
+func DiffGenesis(cdc codec.Codec, a, b *tmtypes.GenesisDoc) ([]GenesisChange, error) {
+	d := &genesisDiffer{}
+
+	d.value("chain_id", a.ChainID, b.ChainID)
+	d.value("genesis_time", a.GenesisTime, b.GenesisTime)
+	d.value("initial_height", a.InitialHeight, b.InitialHeight)
+	consensusParams := make([]interface{}, 2)
+	for i, genDoc := range []*tmtypes.GenesisDoc{a, b} {
+		bz, err := json.Marshal(genDoc.ConsensusParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal consensus params: %w", err)
+		}
+		if consensusParams[i], err = decodeJSON(bz); err != nil {
+			return nil, err
+		}
+	}
+	d.tree("consensus_params", consensusParams[0], consensusParams[1])
+
+	appStates := make([]map[string]json.RawMessage, 2)
+	for i, genDoc := range []*tmtypes.GenesisDoc{a, b} {
+		if err := json.Unmarshal(genDoc.AppState, &appStates[i]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis state: %w", err)
+		}
+	}
+
+	modules := make([]map[string]interface{}, 2)
+	for i, appState := range appStates {
+		modules[i] = map[string]interface{}{}
+		for module := range appState {
+			modules[i][module] = nil
+		}
+	}
+	for _, module := range unionKeys(modules[0], modules[1]) {
+		states := make([]interface{}, 2)
+		for i, appState := range appStates {
+			bz, ok := appState[module]
+			if !ok {
+				continue
+			}
+			if newState, ok := genesisStateTypes[module]; ok {
+				state := newState()
+				if err := cdc.UnmarshalJSON(bz, state); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal %s genesis state: %w", module, err)
+				}
+				var err error
+				if bz, err = cdc.MarshalJSON(state); err != nil {
+					return nil, fmt.Errorf("failed to marshal %s genesis state: %w", module, err)
+				}
+			}
+			var err error
+			if states[i], err = decodeJSON(bz); err != nil {
+				return nil, fmt.Errorf("failed to decode %s genesis state: %w", module, err)
+			}
+		}
+
+		if module == banktypes.ModuleName && states[0] != nil && states[1] != nil {
+			if err := d.bank(cdc, appStates[0][module], appStates[1][module]); err != nil {
+				return nil, err
+			}
+			// Balances and supply are reported as deltas above
+			for _, state := range states {
+				delete(state.(map[string]interface{}), "balances")
+				delete(state.(map[string]interface{}), "supply")
+			}
+		}
+
+		d.tree(module, states[0], states[1])
+	}
+
+	return d.changes, nil
+}
+
+type genesisDiffer struct {
+	changes []GenesisChange
+}
+
+func (d *genesisDiffer) add(path, kind string, from, to interface{}) {
+	change := GenesisChange{Path: path, Kind: kind}
+	if kind != ChangeAdded {
+		change.From = compactJSON(from)
+	}
+	if kind != ChangeRemoved {
+		change.To = compactJSON(to)
+	}
+	d.changes = append(d.changes, change)
+}
+
+func (d *genesisDiffer) value(path string, a, b interface{}) {
+	if compactJSON(a) != compactJSON(b) {
+		d.add(path, ChangeChanged, a, b)
+	}
+}
+
+// tree compares two decoded JSON values. A nil value is a missing one.
+func (d *genesisDiffer) tree(path string, a, b interface{}) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		d.add(path, ChangeAdded, nil, b)
+		return
+	case b == nil:
+		d.add(path, ChangeRemoved, a, nil)
+		return
+	}
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			d.add(path, ChangeChanged, a, b)
+			return
+		}
+		for _, key := range unionKeys(a, b) {
+			d.tree(path+"."+key, a[key], b[key])
+		}
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok {
+			d.add(path, ChangeChanged, a, b)
+			return
+		}
+		if key, ok := keyedLists[path]; ok {
+			d.keyedList(path, a, b, key)
+			return
+		}
+		if len(a) != len(b) {
+			d.add(path, ChangeChanged, a, b)
+			return
+		}
+		for i := range a {
+			d.tree(fmt.Sprintf("%s[%d]", path, i), a[i], b[i])
+		}
+	default:
+		d.value(path, a, b)
+	}
+}
+
+func (d *genesisDiffer) keyedList(path string, a, b []interface{}, key func(interface{}) string) {
+	index := func(list []interface{}) map[string]interface{} {
+		m := map[string]interface{}{}
+		for _, v := range list {
+			m[key(v)] = v
+		}
+		return m
+	}
+	am, bm := index(a), index(b)
+	for _, k := range unionKeys(am, bm) {
+		d.tree(fmt.Sprintf("%s[%s]", path, k), am[k], bm[k])
+	}
+}
+
+// bank reports the balance deltas per address and the supply delta.
+func (d *genesisDiffer) bank(cdc codec.Codec, a, b json.RawMessage) error {
+	states := make([]banktypes.GenesisState, 2)
+	for i, bz := range []json.RawMessage{a, b} {
+		if err := cdc.UnmarshalJSON(bz, &states[i]); err != nil {
+			return fmt.Errorf("failed to unmarshal bank genesis state: %w", err)
+		}
+	}
+
+	balances := make([]map[string]interface{}, 2)
+	for i, state := range states {
+		balances[i] = map[string]interface{}{}
+		for _, balance := range state.Balances {
+			coins, _ := balances[i][balance.Address].(sdk.Coins)
+			balances[i][balance.Address] = coins.Add(balance.Coins...)
+		}
+	}
+	for _, addr := range unionKeys(balances[0], balances[1]) {
+		from, inA := balances[0][addr].(sdk.Coins)
+		to, inB := balances[1][addr].(sdk.Coins)
+		path := fmt.Sprintf("bank.balances[%s]", addr)
+		// Coins are compared per denom as Coins.IsEqual panics on different denoms
+		switch delta := coinsDelta(from, to); {
+		case !inA:
+			d.changes = append(d.changes, GenesisChange{Path: path, Kind: ChangeAdded, To: to.String()})
+		case !inB:
+			d.changes = append(d.changes, GenesisChange{Path: path, Kind: ChangeRemoved, From: from.String()})
+		case delta != "":
+			d.changes = append(d.changes, GenesisChange{Path: path, Kind: ChangeChanged, From: from.String(),
+				To: fmt.Sprintf("%s (%s)", to, delta)})
+		}
+	}
+
+	from, to := states[0].Supply, states[1].Supply
+	if delta := coinsDelta(from, to); delta != "" {
+		d.changes = append(d.changes, GenesisChange{Path: "bank.supply", Kind: ChangeChanged, From: from.String(),
+			To: fmt.Sprintf("%s (%s)", to, delta)})
+	}
+	return nil
+}
+
+// coinsDelta formats the per denom difference from a to b such as +5ucre,-3uatom.
+func coinsDelta(a, b sdk.Coins) string {
+	deltas := []string{}
+	for _, denom := range unionDenoms(a, b) {
+		delta := b.AmountOf(denom).Sub(a.AmountOf(denom))
+		switch {
+		case delta.IsPositive():
+			deltas = append(deltas, "+"+delta.String()+denom)
+		case delta.IsNegative():
+			deltas = append(deltas, delta.String()+denom)
+		}
+	}
+	return strings.Join(deltas, ",")
+}
+
+// jsonField returns the first value of field found in v, searching nested objects.
+func jsonField(v interface{}, field string) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if value, ok := m[field]; ok {
+		if s, ok := value.(string); ok {
+			return s
+		}
+		return compactJSON(value)
+	}
+	for _, key := range unionKeys(m, nil) {
+		if s := jsonField(m[key], field); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func decodeJSON(bz []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func compactJSON(v interface{}) string {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bz)
+}
+
+// unionKeys returns the sorted keys of both JSON objects.
+func unionKeys(a, b map[string]interface{}) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, m := range []map[string]interface{}{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,52 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestDiffGenesis(t *testing.T) {
+	clientCtx := newTestClientCtx(t)
+
+	build := func(unbondingTime time.Duration, faucetCoins string) *tmtypes.GenesisDoc {
+		profile, err := cmd.BuiltinProfile("testnet")
+		require.NoError(t, err)
+		profile.Staking.UnbondingTime = unbondingTime.String()
+		profile.Balances[0].Coins = faucetCoins
+		_, genDoc, _ := prepareProfileGenesis(t, clientCtx, profile, "mooncat-1-1")
+		return genDoc
+	}
+
+	a := build(time.Hour, "100utcre")
+	changes, err := cmd.DiffGenesis(clientCtx.Codec, a, a)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	b := build(2*time.Hour, "150utcre")
+	changes, err = cmd.DiffGenesis(clientCtx.Codec, a, b)
+	require.NoError(t, err)
+	paths := []string{}
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	require.Equal(t, []string{
+		"bank.balances[cre1zzt8k9rrv6qrcjh42tv8k85e5408xmpr63v72h]",
+		"bank.supply",
+		"staking.params.unbonding_time",
+	}, paths)
+	require.Contains(t, changes[0].To, "+50utcre")
+
+	// Balances of the same length but other denoms
+	c := build(time.Hour, "100uatom")
+	changes, err = cmd.DiffGenesis(clientCtx.Codec, a, c)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "bank.balances[cre1zzt8k9rrv6qrcjh42tv8k85e5408xmpr63v72h]", changes[0].Path)
+	require.Contains(t, changes[0].To, "+100uatom,-100utcre")
+	require.Equal(t, "bank.supply", changes[1].Path)
+}
@@ -2,28 +2,47 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	chain "github.com/crescent-network/crescent/app"
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
 )
 
 const (
+	flagClearVesting = "clear-vesting"
 	flagVestingStart = "vesting-start-time"
 	flagVestingEnd   = "vesting-end-time"
 	flagVestingAmt   = "vesting-amount"
+	flagFile         = "file"
+
+	flagVestingType        = "vesting-type"
+	flagVestingSchedule    = "vesting-schedule"
+	flagVestingPeriods     = "vesting-periods"
+	flagVestingPeriodsFile = "vesting-periods-file"
 )
 
 // AddGenesisAccountCmd returns add-genesis-account cobra Command.
@@ -35,6 +54,19 @@ func AddGenesisAccountCmd(defaultNodeHome string) *cobra.Command {
 the account address or key name and a list of initial coins. If a key name is given,
 the address will be looked up in the local Keybase. The list of initial tokens must
 contain valid denominations. Accounts may optionally be supplied with vesting parameters.
+
+The vesting account type is chosen with --vesting-type (continuous|delayed|periodic|permanent-locked)
+or inferred from the other vesting flags. The periods of a periodic vesting account are
+given either as length:coins pairs separated by semicolons, where a length is a number
+of seconds or a fixed length such as 1y, 1mo or 30d, or as a json file of the form
+{"start_time": 1680000000, "periods": [{"length_seconds": 31536000, "coins": "340000ucre"}]},
+or by a vesting schedule template of the builtin network or profile. A periodic vesting
+account starts at the genesis time unless --vesting-start-time is given.
+
+Example:
+$ wrapper add-genesis-account cre1... 1000000ucre --vesting-amount 1000000ucre --vesting-schedule default --network mainnet
+$ wrapper add-genesis-account cre1... 1000000ucre --vesting-periods "1y:340000ucre;1mo:660000ucre"
+$ wrapper add-genesis-account cre1... 1000000ucre --vesting-amount 1000000ucre --vesting-type permanent-locked
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -46,26 +78,9 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 
 			config.SetRoot(clientCtx.HomeDir)
 
-			addr, err := sdk.AccAddressFromBech32(args[0])
+			addr, err := addressFromArg(cmd, clientCtx, args[0])
 			if err != nil {
-				inBuf := bufio.NewReader(cmd.InOrStdin())
-				keyringBackend, err := cmd.Flags().GetString(flags.FlagKeyringBackend)
-				if err != nil {
-					return err
-				}
-
-				// attempt to lookup address from Keybase if no address was provided
-				kb, err := keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, inBuf)
-				if err != nil {
-					return err
-				}
-
-				info, err := kb.Key(args[0])
-				if err != nil {
-					return fmt.Errorf("failed to get address from Keybase: %w", err)
-				}
-
-				addr = info.GetAddress()
+				return err
 			}
 
 			coins, err := sdk.ParseCoinsNormalized(args[1])
@@ -73,54 +88,524 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 				return fmt.Errorf("failed to parse coins: %w", err)
 			}
 
-			vestingStart, err := cmd.Flags().GetInt64(flagVestingStart)
+			vesting, schedules, err := vestingFromFlags(cmd)
 			if err != nil {
 				return err
 			}
-			vestingEnd, err := cmd.Flags().GetInt64(flagVestingEnd)
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			// create concrete account type based on input parameters
+			genAccount, balances, err := newGenesisAccount(addr, coins, vesting, schedules, genDoc.GenesisTime)
 			if err != nil {
 				return err
 			}
-			vestingAmtStr, err := cmd.Flags().GetString(flagVestingAmt)
+
+			accs, err := authtypes.UnpackAccounts(authtypes.GetGenesisStateFromAppState(cdc, appState).Accounts)
 			if err != nil {
+				return fmt.Errorf("failed to get accounts from any: %w", err)
+			}
+
+			if accs.Contains(addr) {
+				return fmt.Errorf("cannot add account at existing address %s", addr)
+			}
+
+			if err := AddGenesisAccounts(cdc, appState, []authtypes.GenesisAccount{genAccount}, []banktypes.Balance{balances}); err != nil {
 				return err
 			}
 
-			vestingAmt, err := sdk.ParseCoinsNormalized(vestingAmtStr)
+			appStateJSON, err := json.Marshal(appState)
 			if err != nil {
-				return fmt.Errorf("failed to parse vesting amount: %w", err)
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
 			}
 
-			// create concrete account type based on input parameters
-			var genAccount authtypes.GenesisAccount
+			genDoc.AppState = appStateJSON
+			return genutil.ExportGenesisFile(genDoc, genFile)
+		},
+	}
 
-			balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
-			baseAccount := authtypes.NewBaseAccount(addr, nil, 0, 0)
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
+	addVestingFlags(cmd)
+	flags.AddQueryFlagsToCmd(cmd)
 
-			if !vestingAmt.IsZero() {
-				baseVestingAccount := authvesting.NewBaseVestingAccount(baseAccount, vestingAmt.Sort(), vestingEnd)
+	return cmd
+}
 
-				if (balances.Coins.IsZero() && !baseVestingAccount.OriginalVesting.IsZero()) ||
-					baseVestingAccount.OriginalVesting.IsAnyGT(balances.Coins) {
-					return errors.New("vesting amount cannot be greater than total amount")
-				}
+// addVestingFlags adds the flags read by vestingFromFlags.
+func addVestingFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagVestingAmt, "", "amount of coins for vesting accounts")
+	cmd.Flags().Int64(flagVestingStart, 0, "schedule start time (unix epoch) for vesting accounts")
+	cmd.Flags().Int64(flagVestingEnd, 0, "schedule end time (unix epoch) for vesting accounts")
+	cmd.Flags().String(flagVestingType, "", "vesting account type (continuous|delayed|periodic|permanent-locked), inferred if empty")
+	cmd.Flags().String(flagVestingSchedule, "", "vesting schedule template turned into the periods of a periodic vesting account")
+	cmd.Flags().String(flagVestingPeriods, "", "periods of a periodic vesting account as length:coins pairs separated by semicolons")
+	cmd.Flags().String(flagVestingPeriodsFile, "", "json file with the start time and periods of a periodic vesting account")
+	cmd.Flags().String(flagNetwork, "", "builtin network type whose vesting schedules are used (mainnet|testnet)")
+	cmd.Flags().String(flagProfile, "", "network profile whose vesting schedules are used")
+}
+
+// vestingFromFlags reads the vesting of a genesis account and the vesting
+// schedules it may refer to from the flags added by addVestingFlags.
+func vestingFromFlags(cmd *cobra.Command) (GenesisAccountVesting, VestingSchedules, error) {
+	vesting := GenesisAccountVesting{}
 
-				switch {
-				case vestingStart != 0 && vestingEnd != 0:
-					genAccount = authvesting.NewContinuousVestingAccountRaw(baseVestingAccount, vestingStart)
+	vestingStart, err := cmd.Flags().GetInt64(flagVestingStart)
+	if err != nil {
+		return vesting, nil, err
+	}
+	vestingEnd, err := cmd.Flags().GetInt64(flagVestingEnd)
+	if err != nil {
+		return vesting, nil, err
+	}
+	vestingAmtStr, err := cmd.Flags().GetString(flagVestingAmt)
+	if err != nil {
+		return vesting, nil, err
+	}
 
-				case vestingEnd != 0:
-					genAccount = authvesting.NewDelayedVestingAccountRaw(baseVestingAccount)
+	vestingAmt, err := sdk.ParseCoinsNormalized(vestingAmtStr)
+	if err != nil {
+		return vesting, nil, fmt.Errorf("failed to parse vesting amount: %w", err)
+	}
 
-				default:
-					return errors.New("invalid vesting parameters; must supply start and end time or end time")
-				}
-			} else {
-				genAccount = baseAccount
+	vesting = GenesisAccountVesting{Amount: vestingAmt, Start: vestingStart, End: vestingEnd}
+	if vesting.Type, err = cmd.Flags().GetString(flagVestingType); err != nil {
+		return vesting, nil, err
+	}
+	if vesting.Schedule, err = cmd.Flags().GetString(flagVestingSchedule); err != nil {
+		return vesting, nil, err
+	}
+	vestingPeriods, err := cmd.Flags().GetString(flagVestingPeriods)
+	if err != nil {
+		return vesting, nil, err
+	}
+	if vesting.Periods, err = ParseVestingPeriods(vestingPeriods); err != nil {
+		return vesting, nil, fmt.Errorf("failed to parse vesting periods: %w", err)
+	}
+	vestingPeriodsFile, err := cmd.Flags().GetString(flagVestingPeriodsFile)
+	if err != nil {
+		return vesting, nil, err
+	}
+	if vestingPeriodsFile != "" {
+		if vestingPeriods != "" {
+			return vesting, nil, fmt.Errorf("--%s cannot be used together with --%s", flagVestingPeriods, flagVestingPeriodsFile)
+		}
+		startTime, periods, err := ReadVestingPeriodsFile(vestingPeriodsFile)
+		if err != nil {
+			return vesting, nil, err
+		}
+		vesting.Periods = periods
+		if vesting.Start == 0 {
+			vesting.Start = startTime
+		}
+	}
+
+	network, err := cmd.Flags().GetString(flagNetwork)
+	if err != nil {
+		return vesting, nil, err
+	}
+	profilePath, err := cmd.Flags().GetString(flagProfile)
+	if err != nil {
+		return vesting, nil, err
+	}
+	schedules, err := loadVestingSchedules(network, profilePath)
+	if err != nil {
+		return vesting, nil, err
+	}
+	return vesting, schedules, nil
+}
+
+// addressFromArg parses a bech32 address, or looks up the address of a key name
+// in the keyring given by --keyring-backend.
+func addressFromArg(cmd *cobra.Command, clientCtx client.Context, arg string) (sdk.AccAddress, error) {
+	addr, err := sdk.AccAddressFromBech32(arg)
+	if err == nil {
+		return addr, nil
+	}
+
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	keyringBackend, err := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	// attempt to lookup address from Keybase if no address was provided
+	kb, err := keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, inBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := kb.Key(arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address from Keybase: %w", err)
+	}
+	return info.GetAddress(), nil
+}
+
+// GenesisAccountVesting is the vesting of a new genesis account.
+type GenesisAccountVesting struct {
+	Type     string              // one of the VestingType constants, inferred if empty
+	Amount   sdk.Coins           // original vesting, the sum of the periods if empty
+	Start    int64               // unix time, the genesis time for periodic accounts if 0
+	End      int64               // unix time, derived from the periods for periodic accounts
+	Schedule string              // schedule template turned into the periods
+	Periods  authvesting.Periods // periods of a periodic account
+}
+
+// newGenesisAccount creates the account and balance of addr. Without a vesting
+// type, the account is a periodic vesting account if a schedule or periods are
+// given, a continuous vesting account if the vesting amount, start and end are
+// given, a delayed vesting account if the vesting amount and end are given, and
+// a base account otherwise.
+func newGenesisAccount(
+	addr sdk.AccAddress, coins sdk.Coins, vesting GenesisAccountVesting, schedules VestingSchedules, genesisTime time.Time,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
+	genAccount, err := newVestingAccount(authtypes.NewBaseAccount(addr, nil, 0, 0), balances.Coins, vesting, schedules, genesisTime)
+	if err != nil {
+		return nil, balances, err
+	}
+	return genAccount, balances, nil
+}
+
+// newVestingAccount turns baseAccount with a balance of coins into the vesting
+// account described by vesting, as in newGenesisAccount.
+func newVestingAccount(
+	baseAccount *authtypes.BaseAccount, coins sdk.Coins, vesting GenesisAccountVesting, schedules VestingSchedules, genesisTime time.Time,
+) (authtypes.GenesisAccount, error) {
+	var genAccount authtypes.GenesisAccount
+
+	vestingType := vesting.Type
+	if vestingType == "" {
+		switch {
+		case vesting.Schedule != "" || len(vesting.Periods) > 0:
+			vestingType = VestingTypePeriodic
+		case vesting.Amount.IsZero():
+		case vesting.Start != 0 && vesting.End != 0:
+			vestingType = VestingTypeContinuous
+		case vesting.End != 0:
+			vestingType = VestingTypeDelayed
+		default:
+			return nil, errors.New("invalid vesting parameters; must supply start and end time or end time")
+		}
+	}
+	if vestingType != VestingTypePeriodic && (vesting.Schedule != "" || len(vesting.Periods) > 0) {
+		return nil, fmt.Errorf("vesting schedule and periods are only supported by %s vesting accounts", VestingTypePeriodic)
+	}
+
+	vestingAmt := vesting.Amount.Sort()
+	switch vestingType {
+	case "":
+		genAccount = baseAccount
+
+	case VestingTypeContinuous:
+		if vestingAmt.IsZero() || vesting.Start == 0 || vesting.End == 0 {
+			return nil, errors.New("continuous vesting accounts require a vesting amount, start and end time")
+		}
+		baseVestingAccount := authvesting.NewBaseVestingAccount(baseAccount, vestingAmt, vesting.End)
+		genAccount = authvesting.NewContinuousVestingAccountRaw(baseVestingAccount, vesting.Start)
+
+	case VestingTypeDelayed:
+		if vestingAmt.IsZero() || vesting.End == 0 {
+			return nil, errors.New("delayed vesting accounts require a vesting amount and end time")
+		}
+		baseVestingAccount := authvesting.NewBaseVestingAccount(baseAccount, vestingAmt, vesting.End)
+		genAccount = authvesting.NewDelayedVestingAccountRaw(baseVestingAccount)
+
+	case VestingTypePermanentLocked:
+		if vestingAmt.IsZero() || vesting.Start != 0 || vesting.End != 0 {
+			return nil, errors.New("permanent locked accounts require a vesting amount and no start or end time")
+		}
+		genAccount = authvesting.NewPermanentLockedAccount(baseAccount, vestingAmt)
+
+	case VestingTypePeriodic:
+		start := vesting.Start
+		if start == 0 {
+			start = genesisTime.Unix()
+		}
+		periods := vesting.Periods
+		switch {
+		case vesting.Schedule != "" && len(periods) > 0:
+			return nil, errors.New("vesting schedule cannot be used together with vesting periods")
+		case vesting.Schedule != "":
+			schedule, err := schedules.Get(vesting.Schedule)
+			if err != nil {
+				return nil, err
+			}
+			if len(vestingAmt) != 1 {
+				return nil, fmt.Errorf("vesting schedule %s requires a vesting amount of a single denom", vesting.Schedule)
+			}
+			if periods, err = schedule.Periods(vestingAmt[0].Amount, vestingAmt[0].Denom); err != nil {
+				return nil, err
 			}
+		case len(periods) == 0:
+			return nil, errors.New("periodic vesting accounts require a vesting schedule or periods")
+		}
+
+		totalVesting := sdk.Coins{}
+		end := start
+		for _, period := range periods {
+			totalVesting = totalVesting.Add(period.Amount...)
+			end += period.Length
+		}
+		// Coins.IsEqual panics on different denoms
+		if !vestingAmt.IsZero() && (!vestingAmt.IsAllLTE(totalVesting) || !totalVesting.IsAllLTE(vestingAmt)) {
+			return nil, fmt.Errorf("vesting amount %s does not match the total of the periods %s", vestingAmt, totalVesting)
+		}
+		if vesting.End != 0 && vesting.End != end {
+			return nil, fmt.Errorf("vesting end time %d does not match the end of the periods %d", vesting.End, end)
+		}
+		vestingAmt = totalVesting
+		genAccount = authvesting.NewPeriodicVestingAccount(baseAccount, vestingAmt, start, periods)
+
+	default:
+		return nil, fmt.Errorf("unknown vesting type %q, expected one of %s, %s, %s or %s",
+			vestingType, VestingTypePeriodic, VestingTypeContinuous, VestingTypeDelayed, VestingTypePermanentLocked)
+	}
+
+	if (coins.IsZero() && !vestingAmt.IsZero()) || vestingAmt.IsAnyGT(coins) {
+		return nil, errors.New("vesting amount cannot be greater than total amount")
+	}
 
-			if err := genAccount.Validate(); err != nil {
-				return fmt.Errorf("failed to validate new genesis account: %w", err)
+	if err := genAccount.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate new genesis account: %w", err)
+	}
+	return genAccount, nil
+}
+
+// ParseVestingPeriods parses vesting periods given as length:coins pairs
+// separated by semicolons. A length is a number of seconds or a fixed length
+// such as 1y, 1mo or 30d. An empty string has no periods.
+func ParseVestingPeriods(s string) (authvesting.Periods, error) {
+	periods := authvesting.Periods{}
+	if strings.TrimSpace(s) == "" {
+		return periods, nil
+	}
+	for i, p := range strings.Split(s, ";") {
+		parts := strings.SplitN(strings.TrimSpace(p), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("period %d: expected length:coins, got %q", i+1, p)
+		}
+		length := int64(0)
+		if secs, err := strconv.ParseInt(parts[0], 10, 64); err == nil {
+			length = secs
+		} else {
+			d := &profileDecoder{}
+			length = d.vestingLength(fmt.Sprintf("period %d", i+1), parts[0])
+			if d.err != nil {
+				return nil, d.err
+			}
+		}
+		if length <= 0 {
+			return nil, fmt.Errorf("period %d: length must be positive", i+1)
+		}
+		coins, err := sdk.ParseCoinsNormalized(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("period %d: %w", i+1, err)
+		}
+		periods = append(periods, authvesting.Period{Length: length, Amount: coins})
+	}
+	return periods, nil
+}
+
+// ReadVestingPeriodsFile reads the start time and periods of a periodic vesting
+// account from a json file.
+func ReadVestingPeriodsFile(filePath string) (int64, authvesting.Periods, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read vesting periods file: %w", err)
+	}
+	var data struct {
+		StartTime int64 `json:"start_time"`
+		Periods   []struct {
+			Length int64  `json:"length_seconds"`
+			Coins  string `json:"coins"`
+		} `json:"periods"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		return 0, nil, fmt.Errorf("failed to decode vesting periods file %s: %w", filePath, err)
+	}
+
+	periods := authvesting.Periods{}
+	for i, p := range data.Periods {
+		if p.Length <= 0 {
+			return 0, nil, fmt.Errorf("%s: period %d: length must be positive", filePath, i+1)
+		}
+		coins, err := sdk.ParseCoinsNormalized(p.Coins)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: period %d: %w", filePath, i+1, err)
+		}
+		periods = append(periods, authvesting.Period{Length: p.Length, Amount: coins})
+	}
+	return data.StartTime, periods, nil
+}
+
+// loadVestingSchedules returns the vesting schedules of the builtin network or
+// the profile, or only the standard default schedule if neither is given.
+func loadVestingSchedules(network, profilePath string) (VestingSchedules, error) {
+	var profile *Profile
+	var err error
+	switch {
+	case network != "" && profilePath != "":
+		return nil, fmt.Errorf("--%s cannot be used together with --%s", flagNetwork, flagProfile)
+	case network != "":
+		profile, err = parseNetworkType(network)
+	case profilePath != "":
+		profile, err = LoadProfile(profilePath)
+	default:
+		return VestingSchedules{DefaultVestingScheduleName: StandardVestingSchedule()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile.VestingSchedules()
+}
+
+// AddGenesisAccounts adds the accounts and their balances to appState and
+// increases the supply by the balances. The accounts must not exist yet.
+func AddGenesisAccounts(cdc codec.Codec, appState map[string]json.RawMessage, genAccounts []authtypes.GenesisAccount, balances []banktypes.Balance) error {
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+
+	// Add the new accounts to the set of genesis accounts and sanitize the
+	// accounts afterwards.
+	accs = append(accs, genAccounts...)
+	accs = authtypes.SanitizeGenesisAccounts(accs)
+
+	genAccs, err := authtypes.PackAccounts(accs)
+	if err != nil {
+		return fmt.Errorf("failed to convert accounts into any's: %w", err)
+	}
+	authGenState.Accounts = genAccs
+
+	authGenStateBz, err := cdc.MarshalJSON(&authGenState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+	}
+
+	appState[authtypes.ModuleName] = authGenStateBz
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	bankGenState.Balances = append(bankGenState.Balances, balances...)
+	bankGenState.Balances = banktypes.SanitizeGenesisBalances(bankGenState.Balances)
+	for _, balance := range balances {
+		bankGenState.Supply = bankGenState.Supply.Add(balance.Coins...)
+	}
+
+	bankGenStateBz, err := cdc.MarshalJSON(bankGenState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bank genesis state: %w", err)
+	}
+
+	appState[banktypes.ModuleName] = bankGenStateBz
+	return nil
+}
+
+// Columns of the accounts file. The first two columns are address and coins;
+// the optional columns are recognized by their header.
+const (
+	accountColumnVestingAmount   = "vesting_amount"
+	accountColumnVestingStart    = "vesting_start_time"
+	accountColumnVestingEnd      = "vesting_end_time"
+	accountColumnVestingType     = "vesting_type"
+	accountColumnVestingSchedule = "vesting_schedule"
+	accountColumnVestingPeriods  = "vesting_periods"
+)
+
+// GenesisAccountRow is a row of the accounts file of add-genesis-accounts.
+type GenesisAccountRow struct {
+	Label           string      `json:"-"` // position of the row in the file, used in errors
+	Address         string      `json:"address"`
+	Coins           string      `json:"coins"`
+	VestingAmount   string      `json:"vesting_amount"`
+	VestingStart    json.Number `json:"vesting_start_time"` // unix time, parsed with the other fields
+	VestingEnd      json.Number `json:"vesting_end_time"`
+	VestingType     string      `json:"vesting_type"`
+	VestingSchedule string      `json:"vesting_schedule"`
+	VestingPeriods  string      `json:"vesting_periods"` // length:coins pairs as in --vesting-periods
+}
+
+// AddGenesisAccountsCmd returns add-genesis-accounts cobra Command.
+func AddGenesisAccountsCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-genesis-accounts",
+		Args:  cobra.NoArgs,
+		Short: "Add genesis accounts from a csv or json file to genesis.json",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Add genesis accounts from a csv or json file to genesis.json in one pass.
+
+Every row has an address or key name and coins, and optionally the vesting
+amount, start time, end time, type, schedule and periods as in
+add-genesis-account. A csv file has the header address,coins and the optional
+columns %s, %s, %s, %s, %s and %s:
+
+  address,coins,vesting_amount,vesting_end_time
+  cre1...,1000000ucre,500000ucre,1680000000
+
+A json file, recognized by its .json extension, is a list of objects with the
+same fields. Schedules are taken from --%s or --%s, or are only the
+standard default schedule. Addresses may appear only once across the file and
+the genesis file. All rows are checked before anything is written, and if any row is
+invalid every error is reported and the genesis file is left unchanged.
+
+Example:
+$ %s add-genesis-accounts --file accounts.csv
+`,
+				accountColumnVestingAmount, accountColumnVestingStart, accountColumnVestingEnd,
+				accountColumnVestingType, accountColumnVestingSchedule, accountColumnVestingPeriods,
+				flagNetwork, flagProfile,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			cdc := clientCtx.Codec
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
+
+			filePath, err := cmd.Flags().GetString(flagFile)
+			if err != nil {
+				return err
+			}
+			if filePath == "" {
+				return fmt.Errorf("--%s is required", flagFile)
+			}
+			keyringBackend, err := cmd.Flags().GetString(flags.FlagKeyringBackend)
+			if err != nil {
+				return err
+			}
+
+			network, err := cmd.Flags().GetString(flagNetwork)
+			if err != nil {
+				return err
+			}
+			profilePath, err := cmd.Flags().GetString(flagProfile)
+			if err != nil {
+				return err
+			}
+
+			rows, err := ReadGenesisAccountsFile(filePath)
+			if err != nil {
+				return err
+			}
+			schedules, err := loadVestingSchedules(network, profilePath)
+			if err != nil {
+				return err
 			}
 
 			genFile := config.GenesisFile()
@@ -129,46 +614,506 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
 			}
 
-			authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
-
-			accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+			accs, err := authtypes.UnpackAccounts(authtypes.GetGenesisStateFromAppState(cdc, appState).Accounts)
 			if err != nil {
 				return fmt.Errorf("failed to get accounts from any: %w", err)
 			}
 
-			if accs.Contains(addr) {
-				return fmt.Errorf("cannot add account at existing address %s", addr)
+			// The keyring is only opened when a row gives a key name
+			var kb keyring.Keyring
+			lookup := func(name string) (sdk.AccAddress, error) {
+				if kb == nil {
+					var err error
+					kb, err = keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, bufio.NewReader(cmd.InOrStdin()))
+					if err != nil {
+						return nil, err
+					}
+				}
+				info, err := kb.Key(name)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get address from Keybase: %w", err)
+				}
+				return info.GetAddress(), nil
+			}
+
+			bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+			genAccounts, balances, rowErrs := NewGenesisAccounts(
+				rows, accs, bankGenState.Balances, schedules, genDoc.GenesisTime, lookup,
+			)
+			if len(rowErrs) > 0 {
+				for _, rowErr := range rowErrs {
+					fmt.Fprintln(cmd.ErrOrStderr(), rowErr)
+				}
+				return fmt.Errorf("%d of %d rows of %s are invalid, genesis file is not changed", len(rowErrs), len(rows), filePath)
+			}
+
+			if err := AddGenesisAccounts(cdc, appState, genAccounts, balances); err != nil {
+				return err
+			}
+
+			appStateJSON, err := json.Marshal(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
 			}
 
-			// Add the new account to the set of genesis accounts and sanitize the
-			// accounts afterwards.
-			accs = append(accs, genAccount)
-			accs = authtypes.SanitizeGenesisAccounts(accs)
+			genDoc.AppState = appStateJSON
+			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added %d genesis accounts\n", len(genAccounts))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
+	cmd.Flags().String(flagFile, "", "csv or json file of the accounts to add")
+	cmd.Flags().String(flagNetwork, "", "builtin network type whose vesting schedules are used (mainnet|testnet)")
+	cmd.Flags().String(flagProfile, "", "network profile whose vesting schedules are used")
+
+	return cmd
+}
+
+// ReadGenesisAccountsFile reads the rows of a csv or json accounts file. A file
+// with the .json extension is read as json.
+func ReadGenesisAccountsFile(filePath string) ([]GenesisAccountRow, error) {
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		bz, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		rows := []GenesisAccountRow{}
+		dec := json.NewDecoder(bytes.NewReader(bz))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rows); err != nil {
+			return nil, fmt.Errorf("failed to decode accounts file %s: %w", filePath, err)
+		}
+		for i := range rows {
+			rows[i].Label = fmt.Sprintf("%s: entry %d", filePath, i+1)
+		}
+		return rows, nil
+	}
+
+	records, err := readCSVFile(filePath, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("accounts file %s is empty", filePath)
+	}
+
+	columns := map[string]int{}
+	for col, name := range records[0][2:] {
+		switch name = strings.TrimSpace(name); name {
+		case accountColumnVestingAmount, accountColumnVestingStart, accountColumnVestingEnd,
+			accountColumnVestingType, accountColumnVestingSchedule, accountColumnVestingPeriods:
+			columns[name] = col + 2
+		default:
+			return nil, newCSVError(filePath, records, 0, col+2, fmt.Errorf("unknown column"))
+		}
+	}
+	// cell returns the value and the column index of an optional column
+	cell := func(r []string, name string) (string, int) {
+		col, ok := columns[name]
+		if !ok || col >= len(r) {
+			return "", col
+		}
+		return strings.TrimSpace(r[col]), col
+	}
+
+	rows := []GenesisAccountRow{}
+	for i, r := range records {
+		if i == 0 {
+			continue
+		}
+		row := GenesisAccountRow{
+			Label:   fmt.Sprintf("%s: row %d", filePath, i+1),
+			Address: strings.TrimSpace(r[0]),
+			Coins:   strings.TrimSpace(r[1]),
+		}
+		row.VestingAmount, _ = cell(r, accountColumnVestingAmount)
+		row.VestingType, _ = cell(r, accountColumnVestingType)
+		row.VestingSchedule, _ = cell(r, accountColumnVestingSchedule)
+		row.VestingPeriods, _ = cell(r, accountColumnVestingPeriods)
+		start, _ := cell(r, accountColumnVestingStart)
+		end, _ := cell(r, accountColumnVestingEnd)
+		row.VestingStart, row.VestingEnd = json.Number(start), json.Number(end)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// NewGenesisAccounts creates the accounts and balances of rows. Addresses that
+// are not bech32 are looked up as key names with lookup. Vesting schedules are
+// looked up in schedules and periodic vesting starts at genesisTime unless the
+// row has a start time. Every invalid row, including addresses repeated in
+// rows or already in existing or existingBalances, is returned as an error and
+// no accounts are returned then.
+func NewGenesisAccounts(
+	rows []GenesisAccountRow, existing authtypes.GenesisAccounts, existingBalances []banktypes.Balance,
+	schedules VestingSchedules, genesisTime time.Time, lookup func(name string) (sdk.AccAddress, error),
+) ([]authtypes.GenesisAccount, []banktypes.Balance, []error) {
+	seen := map[string]string{}
+	for _, acc := range existing {
+		seen[acc.GetAddress().String()] = "the genesis file"
+	}
+	for _, balance := range existingBalances {
+		seen[balance.Address] = "the genesis file"
+	}
+
+	genAccounts := []authtypes.GenesisAccount{}
+	balances := []banktypes.Balance{}
+	errs := []error{}
+	for _, row := range rows {
+		genAccount, balance, err := newGenesisAccountFromRow(row, schedules, genesisTime, lookup)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", row.Label, err))
+			continue
+		}
+		addr := genAccount.GetAddress().String()
+		if other, ok := seen[addr]; ok {
+			errs = append(errs, fmt.Errorf("%s: address %s already exists in %s", row.Label, addr, other))
+			continue
+		}
+		seen[addr] = row.Label
+
+		genAccounts = append(genAccounts, genAccount)
+		balances = append(balances, balance)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+	return genAccounts, balances, nil
+}
+
+func newGenesisAccountFromRow(
+	row GenesisAccountRow, schedules VestingSchedules, genesisTime time.Time, lookup func(name string) (sdk.AccAddress, error),
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	addr, err := sdk.AccAddressFromBech32(row.Address)
+	if err != nil {
+		if addr, err = lookup(row.Address); err != nil {
+			return nil, banktypes.Balance{}, err
+		}
+	}
+
+	coins, err := sdk.ParseCoinsNormalized(row.Coins)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse coins: %w", err)
+	}
+	vestingAmt, err := sdk.ParseCoinsNormalized(row.VestingAmount)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse vesting amount: %w", err)
+	}
+
+	start, err := parseUnixTime(row.VestingStart)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse vesting start time: %w", err)
+	}
+	end, err := parseUnixTime(row.VestingEnd)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse vesting end time: %w", err)
+	}
+
+	periods, err := ParseVestingPeriods(row.VestingPeriods)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse vesting periods: %w", err)
+	}
+
+	vesting := GenesisAccountVesting{
+		Type:     row.VestingType,
+		Amount:   vestingAmt,
+		Start:    start,
+		End:      end,
+		Schedule: row.VestingSchedule,
+		Periods:  periods,
+	}
+	return newGenesisAccount(addr, coins, vesting, schedules, genesisTime)
+}
+
+// parseUnixTime parses an optional unix time, which is 0 if s is empty.
+func parseUnixTime(s json.Number) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := strconv.ParseInt(string(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a unix time", s)
+	}
+	return t, nil
+}
+
+// GenesisAccountUpdate is a change of an existing genesis account.
+type GenesisAccountUpdate struct {
+	TopUp        sdk.Coins              // added to the balance and the supply
+	Vesting      *GenesisAccountVesting // replaces the vesting of the account if not nil
+	ClearVesting bool                   // turns a vesting account into a base account
+}
+
+// UpdateGenesisAccountCmd returns update-genesis-account cobra Command.
+func UpdateGenesisAccountCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-genesis-account [address_or_key_name] [coin][,[coin]]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Top up the coins or change the vesting of a genesis account in genesis.json",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Top up the coins or change the vesting of an existing genesis account in genesis.json.
+
+The given coins are added to the balance of the account and to the supply. If
+any vesting flag is given, the vesting of the account is replaced by the new
+one, computed on the updated balance with the same flags as add-genesis-account.
+--%s turns a vesting account into a base account. The account number,
+sequence and public key of the account are kept.
+
+Example:
+$ %s update-genesis-account cre1... 500000ucre
+$ %s update-genesis-account cre1... --vesting-amount 1000000ucre --vesting-schedule default --network mainnet
+$ %s update-genesis-account cre1... --%s
+`,
+				flagClearVesting,
+				version.AppName, version.AppName, version.AppName, flagClearVesting,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			cdc := clientCtx.Codec
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
 
-			genAccs, err := authtypes.PackAccounts(accs)
+			addr, err := addressFromArg(cmd, clientCtx, args[0])
 			if err != nil {
-				return fmt.Errorf("failed to convert accounts into any's: %w", err)
+				return err
 			}
-			authGenState.Accounts = genAccs
 
-			authGenStateBz, err := cdc.MarshalJSON(&authGenState)
+			update := GenesisAccountUpdate{TopUp: sdk.Coins{}}
+			if len(args) == 2 {
+				if update.TopUp, err = sdk.ParseCoinsNormalized(args[1]); err != nil {
+					return fmt.Errorf("failed to parse coins: %w", err)
+				}
+			}
+			if update.ClearVesting, err = cmd.Flags().GetBool(flagClearVesting); err != nil {
+				return err
+			}
+			vesting, schedules, err := vestingFromFlags(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+				return err
+			}
+			for _, name := range []string{
+				flagVestingAmt, flagVestingStart, flagVestingEnd, flagVestingType,
+				flagVestingSchedule, flagVestingPeriods, flagVestingPeriodsFile,
+			} {
+				if cmd.Flags().Changed(name) {
+					update.Vesting = &vesting
+					break
+				}
 			}
 
-			appState[authtypes.ModuleName] = authGenStateBz
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
 
-			bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
-			bankGenState.Balances = append(bankGenState.Balances, balances)
-			bankGenState.Balances = banktypes.SanitizeGenesisBalances(bankGenState.Balances)
-			bankGenState.Supply = bankGenState.Supply.Add(balances.Coins...)
+			genAccount, balance, err := UpdateGenesisAccount(cdc, appState, addr, update, schedules, genDoc.GenesisTime)
+			if err != nil {
+				return err
+			}
 
-			bankGenStateBz, err := cdc.MarshalJSON(bankGenState)
+			appStateJSON, err := json.Marshal(appState)
 			if err != nil {
-				return fmt.Errorf("failed to marshal bank genesis state: %w", err)
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
 			}
 
-			appState[banktypes.ModuleName] = bankGenStateBz
+			genDoc.AppState = appStateJSON
+			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated %s with balance %s\n", genAccount.GetAddress(), balance.Coins)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
+	cmd.Flags().Bool(flagClearVesting, false, "turn a vesting account into a base account")
+	addVestingFlags(cmd)
+
+	return cmd
+}
+
+// UpdateGenesisAccount applies update to the account at addr in appState. The
+// top up is added to the balance and the supply, and a new vesting is applied
+// to the updated balance. The account number, sequence and public key of the
+// account are kept. An address that only has a balance is treated as a base
+// account. It returns the updated account and balance.
+func UpdateGenesisAccount(
+	cdc codec.Codec, appState map[string]json.RawMessage, addr sdk.AccAddress, update GenesisAccountUpdate,
+	schedules VestingSchedules, genesisTime time.Time,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	if update.Vesting != nil && update.ClearVesting {
+		return nil, banktypes.Balance{}, errors.New("vesting cannot be both changed and cleared")
+	}
+	if update.TopUp.IsZero() && update.Vesting == nil && !update.ClearVesting {
+		return nil, banktypes.Balance{}, errors.New("nothing to update, give coins to top up or a new vesting")
+	}
+
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	index := -1
+	for i, acc := range accs {
+		if acc.GetAddress().Equals(addr) {
+			index = i
+			break
+		}
+	}
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	balance := banktypes.Balance{Address: addr.String(), Coins: sdk.Coins{}}
+	balanceIndex := -1
+	for i, b := range bankGenState.Balances {
+		if b.Address == addr.String() {
+			balance, balanceIndex = b, i
+			break
+		}
+	}
+	if index < 0 && balanceIndex < 0 {
+		return nil, balance, fmt.Errorf("account %s does not exist in the genesis file", addr)
+	}
+	balance.Coins = balance.Coins.Add(update.TopUp...)
+
+	// An address with only a balance gets an account if its vesting changes
+	var genAccount authtypes.GenesisAccount = authtypes.NewBaseAccount(addr, nil, 0, 0)
+	if index >= 0 {
+		genAccount = accs[index]
+	}
+	if update.Vesting != nil || update.ClearVesting {
+		baseAccount, err := baseAccountOf(genAccount)
+		if err != nil {
+			return nil, balance, err
+		}
+		genAccount = baseAccount
+		if update.Vesting != nil {
+			if genAccount, err = newVestingAccount(baseAccount, balance.Coins, *update.Vesting, schedules, genesisTime); err != nil {
+				return nil, balance, err
+			}
+		}
+	}
+	if err := genAccount.Validate(); err != nil {
+		return nil, balance, fmt.Errorf("failed to validate updated genesis account: %w", err)
+	}
+
+	switch {
+	case index >= 0:
+		accs[index] = genAccount
+	case update.Vesting != nil:
+		accs = authtypes.SanitizeGenesisAccounts(append(accs, genAccount))
+	}
+	genAccs, err := authtypes.PackAccounts(accs)
+	if err != nil {
+		return nil, balance, fmt.Errorf("failed to convert accounts into any's: %w", err)
+	}
+	authGenState.Accounts = genAccs
+	authGenStateBz, err := cdc.MarshalJSON(&authGenState)
+	if err != nil {
+		return nil, balance, fmt.Errorf("failed to marshal auth genesis state: %w", err)
+	}
+	appState[authtypes.ModuleName] = authGenStateBz
+
+	if balanceIndex >= 0 {
+		bankGenState.Balances[balanceIndex] = balance
+	} else {
+		bankGenState.Balances = append(bankGenState.Balances, balance)
+	}
+	bankGenState.Balances = banktypes.SanitizeGenesisBalances(bankGenState.Balances)
+	bankGenState.Supply = bankGenState.Supply.Add(update.TopUp...)
+	bankGenStateBz, err := cdc.MarshalJSON(bankGenState)
+	if err != nil {
+		return nil, balance, fmt.Errorf("failed to marshal bank genesis state: %w", err)
+	}
+	appState[banktypes.ModuleName] = bankGenStateBz
+
+	return genAccount, balance, nil
+}
+
+// baseAccountOf returns the base account of a base or vesting account.
+func baseAccountOf(acc authtypes.GenesisAccount) (*authtypes.BaseAccount, error) {
+	switch acc := acc.(type) {
+	case *authtypes.BaseAccount:
+		return acc, nil
+	case *authvesting.ContinuousVestingAccount:
+		return acc.BaseAccount, nil
+	case *authvesting.DelayedVestingAccount:
+		return acc.BaseAccount, nil
+	case *authvesting.PeriodicVestingAccount:
+		return acc.BaseAccount, nil
+	case *authvesting.PermanentLockedAccount:
+		return acc.BaseAccount, nil
+	default:
+		return nil, fmt.Errorf("account %s of type %T cannot be changed", acc.GetAddress(), acc)
+	}
+}
+
+// RemovedGenesisAccount is what RemoveGenesisAccount removed from a genesis file.
+type RemovedGenesisAccount struct {
+	Account      authtypes.GenesisAccount // nil if the address only had a balance or claim records
+	Balance      sdk.Coins
+	ClaimRecords []claimtypes.ClaimRecord
+}
+
+// RemoveGenesisAccountCmd returns remove-genesis-account cobra Command.
+func RemoveGenesisAccountCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-genesis-account [address_or_key_name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Remove a genesis account, its balance and its claim records from genesis.json",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Remove a genesis account, its balance and its claim records from genesis.json.
+
+The balance of the account is removed from the supply. The claimable coins of
+its claim records are removed from the source account of the airdrop and from
+the supply, so that the airdrop sources only fund the remaining claim records.
+Airdrop source accounts, module accounts and the delegators of gentxs cannot be
+removed.
+
+Example:
+$ %s remove-genesis-account cre1...
+`,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			cdc := clientCtx.Codec
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
+
+			addr, err := addressFromArg(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			removed, err := RemoveGenesisAccount(cdc, appState, addr)
+			if err != nil {
+				return err
+			}
 
 			appStateJSON, err := json.Marshal(appState)
 			if err != nil {
@@ -176,15 +1121,157 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 			}
 
 			genDoc.AppState = appStateJSON
-			return genutil.ExportGenesisFile(genDoc, genFile)
+			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Removed %s with balance %s\n", addr, removed.Balance)
+			for _, record := range removed.ClaimRecords {
+				fmt.Fprintf(out, "Removed claim record of airdrop %d, %s claimable\n", record.AirdropId, record.ClaimableCoins)
+			}
+			return nil
 		},
 	}
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
-	cmd.Flags().String(flagVestingAmt, "", "amount of coins for vesting accounts")
-	cmd.Flags().Int64(flagVestingStart, 0, "schedule start time (unix epoch) for vesting accounts")
-	cmd.Flags().Int64(flagVestingEnd, 0, "schedule end time (unix epoch) for vesting accounts")
-	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
 
 	return cmd
 }
+
+// RemoveGenesisAccount removes the account, balance and claim records of addr
+// from appState. The balance is removed from the supply, and the claimable coins
+// of the claim records are removed from the source account of their airdrop and
+// from the supply, so that the sources only fund the remaining claim records.
+// Airdrop source accounts, module accounts and the delegators of gentxs cannot
+// be removed.
+func RemoveGenesisAccount(cdc codec.Codec, appState map[string]json.RawMessage, addr sdk.AccAddress) (*RemovedGenesisAccount, error) {
+	removed := &RemovedGenesisAccount{Balance: sdk.Coins{}, ClaimRecords: []claimtypes.ClaimRecord{}}
+
+	var claimGenState claimtypes.GenesisState
+	if bz, ok := appState[claimtypes.ModuleName]; ok {
+		if err := cdc.UnmarshalJSON(bz, &claimGenState); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal claim genesis state: %w", err)
+		}
+	}
+	sources := map[uint64]string{}
+	for _, airdrop := range claimGenState.Airdrops {
+		if airdrop.SourceAddress == addr.String() {
+			return nil, fmt.Errorf("account %s is the source of airdrop %d", addr, airdrop.Id)
+		}
+		sources[airdrop.Id] = airdrop.SourceAddress
+	}
+
+	if genTxs := genutiltypes.GetGenesisStateFromAppState(cdc, appState).GenTxs; len(genTxs) > 0 {
+		txDecoder := chain.MakeEncodingConfig().TxConfig.TxJSONDecoder()
+		for i, genTx := range genTxs {
+			tx, err := txDecoder(genTx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode gentx %d: %w", i, err)
+			}
+			for _, msg := range tx.GetMsgs() {
+				if msg, ok := msg.(*stakingtypes.MsgCreateValidator); ok && msg.DelegatorAddress == addr.String() {
+					return nil, fmt.Errorf("account %s is the delegator of the gentx of validator %s", addr, msg.ValidatorAddress)
+				}
+			}
+		}
+	}
+
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	remainingAccs := authtypes.GenesisAccounts{}
+	for _, acc := range accs {
+		if !acc.GetAddress().Equals(addr) {
+			remainingAccs = append(remainingAccs, acc)
+			continue
+		}
+		if _, err := baseAccountOf(acc); err != nil {
+			return nil, err
+		}
+		removed.Account = acc
+	}
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	remainingBalances := []banktypes.Balance{}
+	for _, balance := range bankGenState.Balances {
+		if balance.Address == addr.String() {
+			removed.Balance = removed.Balance.Add(balance.Coins...)
+			continue
+		}
+		remainingBalances = append(remainingBalances, balance)
+	}
+	burned := removed.Balance
+
+	remainingRecords := []claimtypes.ClaimRecord{}
+	for _, record := range claimGenState.ClaimRecords {
+		if record.Recipient != addr.String() {
+			remainingRecords = append(remainingRecords, record)
+			continue
+		}
+		source, ok := sources[record.AirdropId]
+		if !ok {
+			return nil, fmt.Errorf("claim record of %s refers to unknown airdrop %d", addr, record.AirdropId)
+		}
+		funded := false
+		for i, balance := range remainingBalances {
+			if balance.Address != source {
+				continue
+			}
+			coins, negative := balance.Coins.SafeSub(record.ClaimableCoins)
+			if negative {
+				return nil, fmt.Errorf("source %s of airdrop %d holds %s, less than the claimable %s of %s",
+					source, record.AirdropId, balance.Coins, record.ClaimableCoins, addr)
+			}
+			remainingBalances[i].Coins = coins
+			funded = true
+			break
+		}
+		if !funded && !record.ClaimableCoins.IsZero() {
+			return nil, fmt.Errorf("source %s of airdrop %d has no balance", source, record.AirdropId)
+		}
+		burned = burned.Add(record.ClaimableCoins...)
+		removed.ClaimRecords = append(removed.ClaimRecords, record)
+	}
+
+	if removed.Account == nil && removed.Balance.IsZero() && len(removed.ClaimRecords) == 0 {
+		return nil, fmt.Errorf("account %s does not exist in the genesis file", addr)
+	}
+
+	supply, negative := bankGenState.Supply.SafeSub(burned)
+	if negative {
+		return nil, fmt.Errorf("supply %s is less than the removed %s", bankGenState.Supply, burned)
+	}
+
+	genAccs, err := authtypes.PackAccounts(remainingAccs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert accounts into any's: %w", err)
+	}
+	authGenState.Accounts = genAccs
+	authGenStateBz, err := cdc.MarshalJSON(&authGenState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal auth genesis state: %w", err)
+	}
+	appState[authtypes.ModuleName] = authGenStateBz
+
+	bankGenState.Balances = banktypes.SanitizeGenesisBalances(remainingBalances)
+	bankGenState.Supply = supply
+	bankGenStateBz, err := cdc.MarshalJSON(bankGenState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bank genesis state: %w", err)
+	}
+	appState[banktypes.ModuleName] = bankGenStateBz
+
+	if len(removed.ClaimRecords) > 0 {
+		claimGenState.ClaimRecords = remainingRecords
+		claimGenStateBz, err := cdc.MarshalJSON(&claimGenState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal claim genesis state: %w", err)
+		}
+		appState[claimtypes.ModuleName] = claimGenStateBz
+	}
+	return removed, nil
+}
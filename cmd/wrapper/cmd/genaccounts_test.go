@@ -0,0 +1,195 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	chain "github.com/crescent-network/crescent/app"
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestNewGenesisAccountsFromFile(t *testing.T) {
+	useCrescentConfig(t)
+
+	addrs := []string{}
+	for i := 0; i < 3; i++ {
+		addrs = append(addrs, sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()).String())
+	}
+	key := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	lookup := func(name string) (sdk.AccAddress, error) {
+		if name == "validator" {
+			return key, nil
+		}
+		return nil, fmt.Errorf("key %s not found", name)
+	}
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	content := "address,coins,vesting_amount,vesting_start_time,vesting_end_time\n" +
+		addrs[0] + ",1000ucre,,,\n" +
+		addrs[1] + ",1000ucre,400ucre,1700000000,1800000000\n" +
+		"validator,10ucre,,,\n"
+	require.NoError(t, os.WriteFile(csvFile, []byte(content), 0600))
+
+	rows, err := cmd.ReadGenesisAccountsFile(csvFile)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	genAccounts, balances, errs := cmd.NewGenesisAccounts(rows, nil, nil, nil, time.Time{}, lookup)
+	require.Empty(t, errs)
+	require.Len(t, genAccounts, 3)
+	require.IsType(t, &authtypes.BaseAccount{}, genAccounts[0])
+	require.IsType(t, &authvesting.ContinuousVestingAccount{}, genAccounts[1])
+	require.Equal(t, key.String(), balances[2].Address)
+
+	jsonFile := filepath.Join(t.TempDir(), "accounts.json")
+	content = `[
+		{"address": "` + addrs[2] + `", "coins": "5ucre"},
+		{"address": "` + addrs[2] + `", "coins": "5ucre"},
+		{"address": "` + addrs[0] + `", "coins": "5ucre"},
+		{"address": "unknown", "coins": "5ucre"},
+		{"address": "` + key.String() + `", "coins": "5ucre", "vesting_amount": "6ucre", "vesting_end_time": 1800000000}
+	]`
+	require.NoError(t, os.WriteFile(jsonFile, []byte(content), 0600))
+
+	rows, err = cmd.ReadGenesisAccountsFile(jsonFile)
+	require.NoError(t, err)
+	existing := authtypes.GenesisAccounts{genAccounts[0]}
+	genAccounts, _, errs = cmd.NewGenesisAccounts(rows, existing, nil, nil, time.Time{}, lookup)
+	require.Nil(t, genAccounts)
+	require.Len(t, errs, 4)
+	require.Contains(t, errs[0].Error(), "entry 2: address "+addrs[2]+" already exists in "+jsonFile+": entry 1")
+	require.Contains(t, errs[1].Error(), "entry 3: address "+addrs[0]+" already exists in the genesis file")
+	require.Contains(t, errs[2].Error(), "entry 4: key unknown not found")
+	require.Contains(t, errs[3].Error(), "entry 5: vesting amount cannot be greater than total amount")
+
+	// Bad times are reported per row, and a balance without an account is taken
+	content = "address,coins,vesting_amount,vesting_start_time,vesting_end_time\n" +
+		addrs[0] + ",1000ucre,,,\n" +
+		addrs[1] + ",1000ucre,400ucre,soon,\n" +
+		addrs[2] + ",1000ucre,400ucre,,later\n"
+	require.NoError(t, os.WriteFile(csvFile, []byte(content), 0600))
+
+	rows, err = cmd.ReadGenesisAccountsFile(csvFile)
+	require.NoError(t, err)
+	existingBalances := []banktypes.Balance{{Address: addrs[0], Coins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 1))}}
+	_, _, errs = cmd.NewGenesisAccounts(rows, nil, existingBalances, nil, time.Time{}, lookup)
+	require.Len(t, errs, 3)
+	require.Contains(t, errs[0].Error(), "row 2: address "+addrs[0]+" already exists in the genesis file")
+	require.Contains(t, errs[1].Error(), "row 3: failed to parse vesting start time: soon is not a unix time")
+	require.Contains(t, errs[2].Error(), "row 4: failed to parse vesting end time: later is not a unix time")
+}
+
+func TestNewGenesisAccountsPeriodicVesting(t *testing.T) {
+	useCrescentConfig(t)
+
+	addrs := []string{}
+	for i := 0; i < 4; i++ {
+		addrs = append(addrs, sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()).String())
+	}
+	schedules := cmd.VestingSchedules{cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule()}
+	genesisTime := time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC)
+
+	rows := []cmd.GenesisAccountRow{
+		{Label: "schedule", Address: addrs[0], Coins: "1000000ucre", VestingAmount: "1000000ucre", VestingSchedule: "default"},
+		{Label: "periods", Address: addrs[1], Coins: "1000000ucre", VestingStart: "1700000000", VestingPeriods: "1y:340000ucre;30d:660000ucre"},
+		{Label: "locked", Address: addrs[2], Coins: "1000000ucre", VestingAmount: "500000ucre", VestingType: "permanent-locked"},
+	}
+	genAccounts, _, errs := cmd.NewGenesisAccounts(rows, nil, nil, schedules, genesisTime, nil)
+	require.Empty(t, errs)
+
+	acc, ok := genAccounts[0].(*authvesting.PeriodicVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, genesisTime.Unix(), acc.StartTime)
+	require.Len(t, acc.VestingPeriods, 25)
+	require.Equal(t, "1000000ucre", acc.OriginalVesting.String())
+
+	acc, ok = genAccounts[1].(*authvesting.PeriodicVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, int64(1700000000+365*24*60*60+30*24*60*60), acc.EndTime)
+	require.Equal(t, "1000000ucre", acc.OriginalVesting.String())
+
+	require.IsType(t, &authvesting.PermanentLockedAccount{}, genAccounts[2])
+
+	rows = []cmd.GenesisAccountRow{
+		{Label: "unknown", Address: addrs[3], Coins: "1000ucre", VestingAmount: "1000ucre", VestingSchedule: "advisor"},
+		{Label: "mismatch", Address: addrs[3], Coins: "1000ucre", VestingAmount: "1000ucre", VestingPeriods: "1y:999ucre"},
+		{Label: "locked", Address: addrs[3], Coins: "1000ucre", VestingAmount: "1000ucre", VestingEnd: "1800000000", VestingType: "permanent-locked"},
+		{Label: "exceeds", Address: addrs[3], Coins: "1000ucre", VestingPeriods: "1y:600ucre;1y:600ucre"},
+		{Label: "denom", Address: addrs[3], Coins: "1000ucre", VestingAmount: "1000ucre", VestingPeriods: "1y:1000uatom"},
+	}
+	_, _, errs = cmd.NewGenesisAccounts(rows, nil, nil, schedules, genesisTime, nil)
+	require.Len(t, errs, 5)
+	require.Contains(t, errs[0].Error(), "advisor")
+	require.Contains(t, errs[1].Error(), "does not match the total of the periods 999ucre")
+	require.Contains(t, errs[2].Error(), "permanent locked accounts require a vesting amount and no start or end time")
+	require.Contains(t, errs[3].Error(), "vesting amount cannot be greater than total amount")
+	require.Contains(t, errs[4].Error(), "vesting amount 1000ucre does not match the total of the periods 1000uatom")
+}
+
+func TestUpdateAndRemoveGenesisAccount(t *testing.T) {
+	cdc := newTestClientCtx(t).Codec
+	appState := chain.ModuleBasics.DefaultGenesis(cdc)
+
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	source := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	genAccounts := []authtypes.GenesisAccount{authtypes.NewBaseAccount(recipient, nil, 3, 0), authtypes.NewBaseAccount(source, nil, 4, 0)}
+	balances := []banktypes.Balance{
+		{Address: recipient.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 200))},
+		{Address: source.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 1000))},
+	}
+	require.NoError(t, cmd.AddGenesisAccounts(cdc, appState, genAccounts, balances))
+
+	claimGenState := claimtypes.GenesisState{
+		Airdrops: []claimtypes.Airdrop{{Id: 1, SourceAddress: source.String()}},
+		ClaimRecords: []claimtypes.ClaimRecord{{
+			AirdropId:             1,
+			Recipient:             recipient.String(),
+			InitialClaimableCoins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 800)),
+			ClaimableCoins:        sdk.NewCoins(sdk.NewInt64Coin("ucre", 800)),
+		}},
+	}
+	appState[claimtypes.ModuleName] = cdc.MustMarshalJSON(&claimGenState)
+
+	vesting := &cmd.GenesisAccountVesting{Type: cmd.VestingTypePermanentLocked, Amount: sdk.NewCoins(sdk.NewInt64Coin("ucre", 250))}
+	update := cmd.GenesisAccountUpdate{TopUp: sdk.NewCoins(sdk.NewInt64Coin("ucre", 100)), Vesting: vesting}
+	genAccount, balance, err := cmd.UpdateGenesisAccount(cdc, appState, recipient, update, nil, time.Time{})
+	require.NoError(t, err)
+	require.IsType(t, &authvesting.PermanentLockedAccount{}, genAccount)
+	require.Equal(t, uint64(3), genAccount.GetAccountNumber())
+	require.Equal(t, "300ucre", balance.Coins.String())
+	require.Equal(t, "1300ucre", banktypes.GetGenesisStateFromAppState(cdc, appState).Supply.String())
+
+	_, err = cmd.RemoveGenesisAccount(cdc, appState, source)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is the source of airdrop 1")
+
+	removed, err := cmd.RemoveGenesisAccount(cdc, appState, recipient)
+	require.NoError(t, err)
+	require.Equal(t, "300ucre", removed.Balance.String())
+	require.Len(t, removed.ClaimRecords, 1)
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	require.Equal(t, []banktypes.Balance{{Address: source.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 200))}}, bankGenState.Balances)
+	require.Equal(t, "200ucre", bankGenState.Supply.String())
+	accs, err := authtypes.UnpackAccounts(authtypes.GetGenesisStateFromAppState(cdc, appState).Accounts)
+	require.NoError(t, err)
+	require.Len(t, accs, 1)
+	cdc.MustUnmarshalJSON(appState[claimtypes.ModuleName], &claimGenState)
+	require.Empty(t, claimGenState.ClaimRecords)
+
+	_, err = cmd.RemoveGenesisAccount(cdc, appState, recipient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not exist in the genesis file")
+}
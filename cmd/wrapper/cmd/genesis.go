@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/version"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -37,9 +40,20 @@ import (
 )
 
 type GenesisStates struct {
-	DEXdropSupply   sdk.Coin
-	BoostdropSupply sdk.Coin
-	BondDenom       string
+	BondDenom string
+
+	// Allocations that make up the total supply, set along with the accounts
+	FoundationSupply   sdk.Coin
+	ValidatorSupply    sdk.Coins
+	VestingSupply      sdk.Coins
+	OtherSupply        sdk.Coins
+	NumVestingAccounts int
+
+	// Airdrops as set in genesis, along with the rows changed by their policy
+	Airdrops []AirdropResult
+
+	// Rows of the vesting file whose address conversion is flagged
+	VestingFlagged []FlaggedAddress
 
 	GenesisTime         time.Time
 	ChainId             string
@@ -61,32 +75,49 @@ type GenesisStates struct {
 	ClaimGenesisState   claimtypes.GenesisState
 }
 
+const (
+	flagProfile       = "profile"
+	flagAirdropFile   = "airdrop-file"
+	flagAirdropReport = "airdrop-report"
+	flagVestingFile   = "vesting-file"
+	flagStrategy      = "strategy"
+)
+
 func PrepareGenesisCmd(defaultNodeHome string, mbm module.BasicManager) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "prepare-genesis [network-type] [chain-id]",
-		Args:    cobra.ExactArgs(2),
+		Args:    cobra.RangeArgs(1, 2),
 		Aliases: []string{"pg"},
 		Short:   "Prepare a genesis file with initial setup",
 		Long: strings.TrimSpace(
 			fmt.Sprintf(`Prepare a genesis file with initial setup.
 
-The initial setup includes initial params for Crescent
+The initial setup includes initial params for Crescent, along with the airdrops,
+vesting accounts and gentx validators, as defined by the builtin mainnet or
+testnet profile or by a YAML or JSON profile given with --%s. The profile
+format is described in the README. Relative paths are resolved against --home.
 
 Example:
 $ %s prepare-genesis mainnet crescent-1
 $ %s prepare-genesis m crescent-1
 $ %s prepare-genesis testnet mooncat-1-1
 $ %s prepare-genesis t mooncat-1-1
+$ %s prepare-genesis --%s ./mainnet.yaml crescent-1 --%s
 
 The genesis output file is at $HOME/.crescent/config/genesis.json
 `,
+				flagProfile,
 				version.AppName,
 				version.AppName,
 				version.AppName,
 				version.AppName,
+				version.AppName, flagProfile, flagCanonical,
 			),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Input errors are reported on their own without the usage text
+			cmd.SilenceUsage = true
+
 			clientCtx := client.GetClientContextFromCmd(cmd)
 			serverCtx := server.GetServerContextFromCmd(cmd)
 
@@ -99,15 +130,147 @@ The genesis output file is at $HOME/.crescent/config/genesis.json
 				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
 			}
 
-			// Parse genesis params depending on the network type
-			networkType := args[0]
-			genStates := parseNetworkType(networkType)
-			if genStates == nil {
-				return fmt.Errorf("you must choose between mainnet (m) or testnet (t): %s", args[0])
+			profilePath, err := cmd.Flags().GetString(flagProfile)
+			if err != nil {
+				return err
+			}
+			airdropFile, err := cmd.Flags().GetString(flagAirdropFile)
+			if err != nil {
+				return err
+			}
+			vestingFile, err := cmd.Flags().GetString(flagVestingFile)
+			if err != nil {
+				return err
+			}
+			airdropReport, err := cmd.Flags().GetString(flagAirdropReport)
+			if err != nil {
+				return err
+			}
+			genTxDir, err := cmd.Flags().GetString(flagGentxDir)
+			if err != nil {
+				return err
+			}
+			strategy, err := cmd.Flags().GetString(flagStrategy)
+			if err != nil {
+				return err
+			}
+			canonical, err := cmd.Flags().GetBool(flagCanonical)
+			if err != nil {
+				return err
+			}
+
+			// Load the profile from the file or depending on the network type
+			var profile *Profile
+			chainID := args[len(args)-1]
+			switch {
+			case profilePath != "" && len(args) == 1:
+				profile, err = LoadProfile(profilePath)
+				if err != nil {
+					return err
+				}
+			case profilePath != "":
+				return fmt.Errorf("network type %s cannot be used together with --%s", args[0], flagProfile)
+			case len(args) == 2:
+				networkType := args[0]
+				profile, err = parseNetworkType(networkType)
+				if err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("either a network type or --%s must be given", flagProfile)
+			}
+
+			// Override and resolve the input files
+			if airdropFile != "" {
+				airdrops := profile.AirdropProfiles()
+				switch len(airdrops) {
+				case 0:
+					return fmt.Errorf("--%s is given but profile %s has no airdrop", flagAirdropFile, profile.Name)
+				case 1:
+					airdrops[0].File = airdropFile
+				default:
+					return fmt.Errorf("--%s is given but profile %s has %d airdrops", flagAirdropFile, profile.Name, len(airdrops))
+				}
+			}
+			if vestingFile != "" {
+				if profile.Vesting == nil {
+					profile.Vesting = &VestingProfile{}
+				}
+				profile.Vesting.File = vestingFile
+			}
+			if genTxDir != "" {
+				if profile.GenTxs == nil {
+					profile.GenTxs = &GenTxProfile{}
+				}
+				profile.GenTxs.Dir = genTxDir
+			}
+			if err := profile.ResolveFiles(serverCfg.RootDir); err != nil {
+				return err
+			}
+
+			// Parse genesis params from the profile
+			genStates, err := profile.GenesisStates()
+			if err != nil {
+				return fmt.Errorf("failed to build genesis states from profile %s: %w", profile.Name, err)
+			}
+
+			// Apply the strategy to the existing accounts
+			conflicts, err := MergeExistingAccounts(clientCtx.Codec, appState, genStates, strategy)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, conflict := range conflicts {
+				fmt.Fprintln(out, "conflict:", conflict)
+			}
+			for _, airdrop := range genStates.Airdrops {
+				name := fmt.Sprint(airdrop.Id)
+				if airdrop.Name != "" {
+					name = fmt.Sprintf("%d (%s)", airdrop.Id, airdrop.Name)
+				}
+				fmt.Fprintf(out, "Airdrop %s : %d recipients, genesis %s, claimable %s, reserve %s\n",
+					name, airdrop.Recipients, airdrop.Genesis, airdrop.Claimable, airdrop.Reserve)
+				if len(airdrop.Adjustments) > 0 {
+					counts := map[string]int{}
+					for _, a := range airdrop.Adjustments {
+						counts[a.Rule]++
+					}
+					for _, rule := range []string{
+						AirdropRuleFlagged, AirdropRuleExcluded, AirdropRuleBelowMinimum, AirdropRuleRoundedUp, AirdropRuleCapped, AirdropRuleRedistributed,
+					} {
+						if counts[rule] > 0 {
+							fmt.Fprintf(out, "Airdrop %d %s : %d rows\n", airdrop.Id, rule, counts[rule])
+						}
+					}
+				}
+				if airdrop.Burned.IsPositive() {
+					fmt.Fprintf(out, "Airdrop %d burned : %s\n", airdrop.Id, airdrop.Burned)
+				}
+				if airdrop.Dust.IsPositive() {
+					fmt.Fprintf(out, "Airdrop %d dust : %s (rounded down from the genesis balances, claimable instead)\n", airdrop.Id, airdrop.Dust)
+				}
+			}
+			if airdropReport != "" {
+				var buf bytes.Buffer
+				if err := WriteAirdropReport(&buf, genStates.Airdrops); err != nil {
+					return err
+				}
+				if err := os.WriteFile(airdropReport, buf.Bytes(), 0644); err != nil {
+					return fmt.Errorf("failed to write airdrop report: %w", err)
+				}
 			}
+			fmt.Fprintln(out, "FoundationSupply :", genStates.FoundationSupply)
+			fmt.Fprintln(out, "ValidatorBalances :", genStates.ValidatorSupply)
+			fmt.Fprintln(out, "OtherBalances :", genStates.OtherSupply)
+			fmt.Fprintln(out, "totalVestingAmt :", genStates.VestingSupply)
+			fmt.Fprintln(out, "len(vestingAccs) :", genStates.NumVestingAccounts)
+			for _, f := range genStates.VestingFlagged {
+				fmt.Fprintf(out, "Vesting row %d flagged : %s converted to %s (%s)\n", f.Row, f.Address, f.Converted, f.Reason)
+			}
+			fmt.Fprintln(out, "TotalSupply :", genStates.BankGenesisStates.Supply)
 
 			// Prepare genesis
-			chainID := args[1]
 			appState, genDoc, err = PrepareGenesis(clientCtx, appState, genDoc, genStates, chainID)
 			if err != nil {
 				return fmt.Errorf("failed to prepare genesis %w", err)
@@ -126,6 +289,15 @@ The genesis output file is at $HOME/.crescent/config/genesis.json
 			genDoc.AppState = appStateJSON
 
 			// Export the genesis state to a file
+			if canonical {
+				hashes, err := ExportCanonicalGenesisFile(genDoc, genFile)
+				if err != nil {
+					return fmt.Errorf("failed to export genesis file %w", err)
+				}
+				fmt.Fprintln(out, "FileSHA256 :", hashes.File)
+				fmt.Fprintln(out, "AppStateSHA256 :", hashes.AppState)
+				return nil
+			}
 			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
 				return fmt.Errorf("failed to export genesis file %w", err)
 			}
@@ -135,6 +307,13 @@ The genesis output file is at $HOME/.crescent/config/genesis.json
 	}
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagProfile, "", "Path to a YAML or JSON network profile to use instead of a builtin network type")
+	cmd.Flags().String(flagAirdropFile, "", "Airdrop result csv file overriding the profile, absolute or relative to --home")
+	cmd.Flags().String(flagVestingFile, "", "Vesting csv file overriding the profile, absolute or relative to --home")
+	cmd.Flags().String(flagAirdropReport, "", "Write the airdrop rows adjusted by the airdrop policy or flagged by the address policy to the given csv file")
+	cmd.Flags().String(flagGentxDir, "", "Gentx directory to fund and whitelist the validators from, overriding gentxs.dir of the profile")
+	cmd.Flags().String(flagStrategy, StrategyReplace, "How to handle accounts already in the genesis file: drop them, add up their balances, or abort (replace|merge|fail-on-existing)")
+	cmd.Flags().Bool(flagCanonical, false, "Write the genesis file as canonical JSON and print its SHA-256")
 	flags.AddQueryFlagsToCmd(cmd)
 
 	return cmd
@@ -240,21 +419,53 @@ func PrepareGenesis(
 	return appState, genDoc, nil
 }
 
-// parseNetworkType returns GenesisStates based on the network type.
-func parseNetworkType(networkType string) *GenesisStates {
+// parseNetworkType returns the builtin profile of the network type.
+func parseNetworkType(networkType string) (*Profile, error) {
 	switch strings.ToLower(networkType) {
 	case "t", "testnet":
-		// return TestnetGenesisStates()
-		return &GenesisStates{}
+		return BuiltinProfile("testnet")
 	case "m", "mainnet":
-		return MainnetGenesisStates()
+		return BuiltinProfile("mainnet")
 	default:
-		return nil
+		return nil, fmt.Errorf("you must choose between mainnet (m) or testnet (t): %s", networkType)
+	}
+}
+
+// CSVError reports an invalid value in a csv input file.
+type CSVError struct {
+	File   string
+	Row    int    // 1-based row number, the header being row 1
+	Column string // column name taken from the header
+	Value  string
+	Err    error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("%s: row %d, column %s: invalid value %q: %v", e.File, e.Row, e.Column, e.Value, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// newCSVError returns a CSVError for the given column of records[row].
+func newCSVError(filePath string, records [][]string, row, col int, err error) *CSVError {
+	column := strconv.Itoa(col + 1)
+	if len(records) > 0 && col < len(records[0]) {
+		column = records[0][col]
+	}
+	return &CSVError{
+		File:   filePath,
+		Row:    row + 1,
+		Column: column,
+		Value:  records[row][col],
+		Err:    err,
 	}
 }
 
 // readCSVFile reads csv file and returns all the records.
-func readCSVFile(filePath string) ([][]string, error) {
+// Every record must have at least minColumns columns.
+func readCSVFile(filePath string, minColumns int) ([][]string, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
@@ -262,18 +473,66 @@ func readCSVFile(filePath string) ([][]string, error) {
 	defer f.Close()
 
 	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
 	records, err := r.ReadAll()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read csv file %s: %w", filePath, err)
+	}
+	for i, record := range records {
+		if len(record) < minColumns {
+			return nil, fmt.Errorf("%s: row %d: expected at least %d columns, got %d", filePath, i+1, minColumns, len(record))
+		}
 	}
 	return records, nil
 }
 
+// convertAddressPrefix re-encodes a bech32 address with the account address prefix.
+func convertAddressPrefix(addr string) (string, error) {
+	_, converted, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return "", err
+	}
+
+	targetPrefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
+	return bech32.ConvertAndEncode(targetPrefix, converted)
+}
+
+// parseAmount parses a non-negative integer amount.
+func parseAmount(s string) (sdk.Int, error) {
+	amt, ok := sdk.NewIntFromString(s)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("not an integer")
+	}
+	if amt.IsNegative() {
+		return sdk.Int{}, fmt.Errorf("negative amount")
+	}
+	return amt, nil
+}
+
+// ParseOffsetTime parses either an RFC3339 time or a calendar offset from base
+// such as 0, 1y, 6mo, 1y6mo or 30d.
+func ParseOffsetTime(base time.Time, s string) (time.Time, error) {
+	if s == "0" {
+		return base, nil
+	}
+	m := offsetRegexp.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return time.Parse(time.RFC3339, s)
+	}
+	n := make([]int, 3)
+	for i, v := range m[1:] {
+		if v != "" {
+			n[i], _ = strconv.Atoi(v)
+		}
+	}
+	return base.AddDate(n[0], n[1], n[2]), nil
+}
+
 // ParseTime parses and returns time.Time in time.RFC3339 format.
-func ParseTime(s string) time.Time {
+func ParseTime(s string) (time.Time, error) {
 	t, err := time.Parse(time.RFC3339, s)
 	if err != nil {
-		panic(err)
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", s, err)
 	}
-	return t
+	return t, nil
 }
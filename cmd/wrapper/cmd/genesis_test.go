@@ -0,0 +1,43 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+
+	chain "github.com/crescent-network/crescent/app"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestPrepareGenesisDeterministic(t *testing.T) {
+	clientCtx := newTestClientCtx(t)
+
+	airdropFile := filepath.Join(t.TempDir(), "result.csv")
+	content := "address,amount\n" +
+		"cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,1000000003\n" +
+		"cosmos15u8u9zmjlnl98075cadwjgyrejqyfq69mj2hrc,55555\n"
+	require.NoError(t, os.WriteFile(airdropFile, []byte(content), 0600))
+
+	profile, err := cmd.BuiltinProfile("mainnet")
+	require.NoError(t, err)
+	profile.Airdrop.File = airdropFile
+	profile.Vesting.File = vestingFilePathTest
+
+	build := func() []byte {
+		appState, genDoc, _ := prepareProfileGenesis(t, clientCtx, profile, "crescent-1")
+		require.NoError(t, chain.ModuleBasics.ValidateGenesis(clientCtx.Codec, clientCtx.TxConfig, appState))
+
+		bz, err := tmjson.Marshal(genDoc)
+		require.NoError(t, err)
+		return bz
+	}
+
+	first := build()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, first, build())
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	chain "github.com/crescent-network/crescent/app"
+)
+
+// DefaultWhitelistTargetWeight is the liquid staking target weight of gentx
+// validators whose weight is not configured.
+const DefaultWhitelistTargetWeight = 10
+
+// GenTxValidator is a validator created by a gentx.
+type GenTxValidator struct {
+	File             string
+	DelegatorAddress string
+	ValidatorAddress string
+	SelfDelegation   sdk.Coin
+}
+
+// ParseGenTxValidators reads the gentx json files of dir in file name order and
+// returns the validators they create. Every gentx must hold a single
+// MsgCreateValidator delegating bondDenom.
+func ParseGenTxValidators(dir, bondDenom string) ([]GenTxValidator, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gentx directory: %w", err)
+	}
+
+	txDecoder := chain.MakeEncodingConfig().TxConfig.TxJSONDecoder()
+	validators := []GenTxValidator{}
+	seen := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		_, msg, err := readGenTx(txDecoder, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if _, err := sdk.AccAddressFromBech32(msg.DelegatorAddress); err != nil {
+			return nil, fmt.Errorf("gentx %s has an invalid delegator address %s: %w", path, msg.DelegatorAddress, err)
+		}
+		if _, err := sdk.ValAddressFromBech32(msg.ValidatorAddress); err != nil {
+			return nil, fmt.Errorf("gentx %s has an invalid validator address %s: %w", path, msg.ValidatorAddress, err)
+		}
+		if msg.Value.Denom != bondDenom {
+			return nil, fmt.Errorf("gentx %s delegates %s, expected the bond denom %s", path, msg.Value.Denom, bondDenom)
+		}
+		if other, ok := seen[msg.ValidatorAddress]; ok {
+			return nil, fmt.Errorf("gentx %s creates validator %s again, first created by %s", path, msg.ValidatorAddress, other)
+		}
+		seen[msg.ValidatorAddress] = path
+
+		validators = append(validators, GenTxValidator{
+			File:             path,
+			DelegatorAddress: msg.DelegatorAddress,
+			ValidatorAddress: msg.ValidatorAddress,
+			SelfDelegation:   msg.Value,
+		})
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("gentx directory %s has no gentx", dir)
+	}
+	return validators, nil
+}
+
+// readGenTx decodes the gentx at path, which must hold a single MsgCreateValidator.
+func readGenTx(txDecoder sdk.TxDecoder, path string) (sdk.Tx, *stakingtypes.MsgCreateValidator, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read gentx: %w", err)
+	}
+	tx, err := txDecoder(bz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode gentx: %w", err)
+	}
+	msgs := tx.GetMsgs()
+	if len(msgs) != 1 {
+		return nil, nil, fmt.Errorf("gentx must contain exactly one message, got %d", len(msgs))
+	}
+	msg, ok := msgs[0].(*stakingtypes.MsgCreateValidator)
+	if !ok {
+		return nil, nil, fmt.Errorf("gentx must contain a MsgCreateValidator, got %T", msgs[0])
+	}
+	return tx, msg, nil
+}
+
+// topUpValidatorBalances makes sure the account of every gentx validator holds
+// at least funding of bondDenom, or its self-delegation if funding is nil. The
+// existing balances and original vesting amounts count towards it, and only the
+// difference is added, to the existing balance of the account if there is one.
+// It returns the balances and the total amount added.
+func topUpValidatorBalances(
+	balances []banktypes.Balance, vestingAccsMap map[string]VestingGenesisAccount,
+	validators []GenTxValidator, funding *sdk.Int, bondDenom string,
+) ([]banktypes.Balance, sdk.Coins, error) {
+	total := sdk.Coins{}
+	for _, validator := range validators {
+		required := validator.SelfDelegation.Amount
+		if funding != nil {
+			if funding.LT(required) {
+				return nil, nil, fmt.Errorf("funding %s is less than the self-delegation %s of validator %s",
+					funding, validator.SelfDelegation, validator.ValidatorAddress)
+			}
+			required = *funding
+		}
+
+		existing := sdk.ZeroInt()
+		index := -1
+		for i, balance := range balances {
+			if balance.Address == validator.DelegatorAddress {
+				existing = existing.Add(balance.Coins.AmountOf(bondDenom))
+				index = i
+			}
+		}
+		if vestingAcc, ok := vestingAccsMap[validator.DelegatorAddress]; ok {
+			existing = existing.Add(vestingAcc.GetOriginalVesting().AmountOf(bondDenom))
+		}
+		if existing.GTE(required) {
+			continue
+		}
+
+		topUp := sdk.NewCoins(sdk.NewCoin(bondDenom, required.Sub(existing)))
+		if index >= 0 {
+			balances[index].Coins = balances[index].Coins.Add(topUp...)
+		} else {
+			balances = append(balances, banktypes.Balance{Address: validator.DelegatorAddress, Coins: topUp})
+		}
+		total = total.Add(topUp...)
+	}
+	return balances, total, nil
+}
@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestGenTxValidators(t *testing.T) {
+	clientCtx := newTestClientCtx(t)
+	dir := t.TempDir()
+
+	airdropFile := filepath.Join(dir, "result.csv")
+	content := "address,amount\n" +
+		"cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,1000000003\n"
+	require.NoError(t, os.WriteFile(airdropFile, []byte(content), 0600))
+	recipient, err := sdk.GetFromBech32("cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx", "cosmos")
+	require.NoError(t, err)
+
+	gentxDir := filepath.Join(dir, "gentx")
+	require.NoError(t, os.Mkdir(gentxDir, 0700))
+	writeGenTx := func(name string, addr sdk.AccAddress, selfDelegation int64) {
+		msg, err := stakingtypes.NewMsgCreateValidator(
+			sdk.ValAddress(addr), ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin("ucre", selfDelegation),
+			stakingtypes.Description{Moniker: name},
+			stakingtypes.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()), sdk.OneInt(),
+		)
+		require.NoError(t, err)
+		txBuilder := clientCtx.TxConfig.NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(msg))
+		bz, err := clientCtx.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(gentxDir, name+".json"), bz, 0600))
+	}
+	newValidator := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	writeGenTx("a", recipient, 1000000)
+	writeGenTx("b", newValidator, 1500000)
+
+	profile, err := cmd.BuiltinProfile("mainnet")
+	require.NoError(t, err)
+	profile.Airdrop.File = airdropFile
+	profile.Vesting = nil
+	profile.ValidatorBalances = nil
+	profile.LiquidStaking.WhitelistedValidators = profile.LiquidStaking.WhitelistedValidators[:1]
+	profile.GenTxs = &cmd.GenTxProfile{Dir: gentxDir, Funding: "2_000_000", TargetWeight: "5"}
+
+	genStates, err := profile.GenesisStates()
+	require.NoError(t, err)
+
+	balances := map[string]sdk.Coins{}
+	for _, balance := range genStates.BankGenesisStates.Balances {
+		require.NotContains(t, balances, balance.Address)
+		balances[balance.Address] = balance.Coins
+	}
+	// The airdrop recipient already holds 200000000ucre, the new validator is funded.
+	require.Equal(t, "200000000ucre", balances[sdk.AccAddress(recipient).String()].String())
+	require.Equal(t, "2000000ucre", balances[newValidator.String()].String())
+	require.Equal(t, "2000000ucre", genStates.ValidatorSupply.String())
+
+	total := sdk.Coins{}
+	for _, coins := range balances {
+		total = total.Add(coins...)
+	}
+	require.Equal(t, genStates.BankGenesisStates.Supply, total)
+
+	whitelist := genStates.LiquidStakingParams.WhitelistedValidators
+	require.Len(t, whitelist, 3)
+	require.Equal(t, sdk.ValAddress(recipient).String(), whitelist[1].ValidatorAddress)
+	require.Equal(t, sdk.NewInt(5), whitelist[2].TargetWeight)
+
+	// Funding below a self-delegation is an error
+	profile.GenTxs.Funding = "1_000"
+	_, err = profile.GenesisStates()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "less than the self-delegation")
+}
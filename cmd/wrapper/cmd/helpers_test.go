@@ -0,0 +1,90 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	chain "github.com/crescent-network/crescent/app"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+const (
+	bondDenom           = "ucre"
+	vestingFilePathTest = "../../../data/vesting_test.csv"
+)
+
+// useCrescentConfig sets the crescent bech32 prefixes until the test ends.
+func useCrescentConfig(t *testing.T) {
+	cmd.GetConfig()
+	t.Cleanup(func() {
+		sdk.GetConfig().SetBech32PrefixForAccount(sdk.Bech32PrefixAccAddr, sdk.Bech32PrefixAccPub)
+	})
+}
+
+// newTestClientCtx sets the crescent bech32 prefixes and returns a client
+// context with the codec and tx config of the app.
+func newTestClientCtx(t *testing.T) client.Context {
+	useCrescentConfig(t)
+	encodingConfig := chain.MakeEncodingConfig()
+	return client.Context{}.
+		WithCodec(encodingConfig.Marshaler).
+		WithTxConfig(encodingConfig.TxConfig)
+}
+
+// prepareTestGenesis prepares the genesis of the builtin testnet profile.
+func prepareTestGenesis(t *testing.T) (client.Context, map[string]json.RawMessage, *tmtypes.GenesisDoc, *cmd.GenesisStates) {
+	clientCtx := newTestClientCtx(t)
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+	appState, genDoc, genStates := prepareProfileGenesis(t, clientCtx, profile, "mooncat-1-1")
+	return clientCtx, appState, genDoc, genStates
+}
+
+// prepareProfileGenesis prepares the genesis of profile on the default genesis
+// of the app, with the app state also set on the genesis doc.
+func prepareProfileGenesis(t *testing.T, clientCtx client.Context, profile *cmd.Profile, chainId string) (map[string]json.RawMessage, *tmtypes.GenesisDoc, *cmd.GenesisStates) {
+	genStates, err := profile.GenesisStates()
+	require.NoError(t, err)
+	appState := chain.ModuleBasics.DefaultGenesis(clientCtx.Codec)
+	appState, genDoc, err := cmd.PrepareGenesis(clientCtx, appState, &tmtypes.GenesisDoc{}, genStates, chainId)
+	require.NoError(t, err)
+	genDoc.AppState, err = json.Marshal(appState)
+	require.NoError(t, err)
+	return appState, genDoc, genStates
+}
+
+// signGenTx creates a validator key uid in kr and returns its address and a
+// gentx creating the validator, signed for chainId.
+func signGenTx(t *testing.T, clientCtx client.Context, kr keyring.Keyring, chainId, uid, moniker, rate string, selfDelegation sdk.Coin) (sdk.AccAddress, []byte) {
+	info, _, err := kr.NewMnemonic(uid, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(info.GetAddress()), ed25519.GenPrivKey().PubKey(), selfDelegation,
+		stakingtypes.Description{Moniker: moniker},
+		stakingtypes.NewCommissionRates(sdk.MustNewDecFromStr(rate), sdk.OneDec(), sdk.MustNewDecFromStr("0.01")), sdk.OneInt(),
+	)
+	require.NoError(t, err)
+	txBuilder := clientCtx.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msg))
+	txFactory := clienttx.Factory{}.
+		WithKeybase(kr).
+		WithChainID(chainId).
+		WithTxConfig(clientCtx.TxConfig).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, clienttx.Sign(txFactory, uid, txBuilder, true))
+	bz, err := clientCtx.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+	return info.GetAddress(), bz
+}
@@ -2,417 +2,146 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/types/bech32"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
 	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
-	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
-	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
-	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
-	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	claimtypes "github.com/crescent-network/crescent/x/claim/types"
-	farmingtypes "github.com/crescent-network/crescent/x/farming/types"
-	liquiditytypes "github.com/crescent-network/crescent/x/liquidity/types"
-	liquidstakingtypes "github.com/crescent-network/crescent/x/liquidstaking/types"
-	minttypes "github.com/crescent-network/crescent/x/mint/types"
-	budgettypes "github.com/tendermint/budget/x/budget/types"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
-var (
-	filePath            = "./data/result.csv"              // airdrop result file
-	VestingFilePath     = "./data/vesting.csv"             // vesting file
-	VestingFilePathTest = "../../../data/vesting_test.csv" // vesting file
-)
-
-var (
-	FarmingFeeCollector           = "cre1h292smhhttwy0rl3qr4p6xsvpvxc4v05s6rxtczwq3cs6qc462mq4p6cjy"
-	LiquidityFeeCollectorAddress  = "cre1zdew6yxyw92z373yqp756e0x4rvd2het37j0a2wjp7fj48eevxvq303p8d"
-	LiquidityDustCollectorAddress = "cre1suads2mkd027cmfphmk9fpuwcct4d8ys02frk8e64hluswfwfj0s4xymnj"
-	InflationFeeCollector         = "cre17xpfvakm2amg962yls6f84z3kell8c5l53s97s"
-	EcosystemIncentive            = "cre1kgshua58cjr2p7hnrvgun68yrqf7ktdzyz2yxv54fqj6uwl4gc4q95txqa"
-	EcosystemIncentiveLP          = "cre1wht0xhmuqph4rhzulhejgatthnpeatzjgnnkvqvphq97xr26np0qdvun2s"
-	EcosystemIncentiveMM          = "cre1ddn66jv0sjpmck0ptegmhmqtn35qsg2vxyk2hn9sqf4qxtzqz3sq3qhhde"
-	EcosystemIncentiveBoost       = "cre17zftu6rg7mkmemqxv4whjkvecl0e2ja7j6um9t8qaczp79y72d7q2su2xm"
-	AirdropSourceAddress          = "cre1rq9dzurree0ruj4xvuss33ysfus3lkneg3jnfdsy4ah8gxjta3mqlr2sax"
-	FoundationAddress             = "cre1u9jxn6l7seq5jjej4w6etpdxufphwfuunljr4e" // multisig
-	DevTeamAddress                = "cre1ge2jm9nkvu2l8cvhc2un4m33d4yy4p0wfag09j" // multisig
-)
-
-var (
-	GenesisTime      = "2022-04-13T00:00:00Z"
-	GenesisTimeUnix  = ParseTime(GenesisTime).Unix()
-	BondDenom        = "ucre"
-	LiquidBondDenom  = "ubcre"
-	FoundationSupply = sdk.NewInt(100_000_000_000_000) // 100mil
-	DEXDropSupply    = sdk.NewInt(50_000_000_000_000)  // 50mil
-	BoostDropSupply  = sdk.NewInt(50_000_000_000_000)  // 50mil
-)
-
-func MainnetGenesisStates() *GenesisStates {
-	genParams := &GenesisStates{}
-	genParams.BondDenom = BondDenom
-	genParams.DEXdropSupply = sdk.NewCoin(genParams.BondDenom, DEXDropSupply)     // 50mil
-	genParams.BoostdropSupply = sdk.NewCoin(genParams.BondDenom, BoostDropSupply) // 50mil
-
-	// Set genesis time
-	genParams.GenesisTime = ParseTime(GenesisTime)
-
-	// Set consensus params
-	genParams.ConsensusParams = &tmproto.ConsensusParams{
-		Block: tmproto.BlockParams{
-			MaxBytes:   10000000,
-			MaxGas:     100000000,
-			TimeIotaMs: 1000,
-		},
-		Evidence: tmproto.EvidenceParams{
-			MaxAgeNumBlocks: 201600,
-			MaxAgeDuration:  1209600000000000,
-			MaxBytes:        1000000,
-		},
-		Validator: tmproto.ValidatorParams{
-			PubKeyTypes: []string{"ed25519"},
-		},
-		Version: tmproto.VersionParams{},
-	}
-
-	// Set auth params
-	genParams.AuthParams = authtypes.DefaultParams()
-	genParams.AuthParams.MaxMemoCharacters = 512
-
-	// Set bank params
-	genParams.BankParams = banktypes.DefaultParams()
-
-	// Set crisis genesis states
-	genParams.CrisisStates = crisistypes.GenesisState{
-		ConstantFee: sdk.NewInt64Coin(genParams.BondDenom, 1000),
+// MainnetGenesisStates returns GenesisStates built from the builtin mainnet profile.
+func MainnetGenesisStates() (*GenesisStates, error) {
+	profile, err := BuiltinProfile("mainnet")
+	if err != nil {
+		return nil, err
 	}
+	return profile.GenesisStates()
+}
 
-	// Set distribution params
-	genParams.DistributionParams = distrtypes.Params{
-		CommunityTax:        sdk.MustNewDecFromStr("0.285714285700000000"),
-		BaseProposerReward:  sdk.MustNewDecFromStr("0.007142857143000000"),
-		BonusProposerReward: sdk.MustNewDecFromStr("0.028571428570000000"),
-		WithdrawAddrEnabled: true,
+// TestnetGenesisStates returns GenesisStates built from the builtin testnet profile.
+func TestnetGenesisStates() (*GenesisStates, error) {
+	profile, err := BuiltinProfile("testnet")
+	if err != nil {
+		return nil, err
 	}
+	return profile.GenesisStates()
+}
 
-	// Set staking params
-	genParams.StakingParams = stakingtypes.Params{
-		UnbondingTime:     1209600 * time.Second, // 2 weeks
-		MaxValidators:     50,
-		MaxEntries:        28,
-		HistoricalEntries: 10000,
-		BondDenom:         genParams.BondDenom,
-	}
+// airdropInput is an airdrop of a profile and the file of its recipients.
+type airdropInput struct {
+	Name         string
+	Airdrop      claimtypes.Airdrop
+	File         string
+	Policy       *AirdropPolicy
+	GenesisRatio sdk.Dec  // part of every row set as genesis balance, the rest is claimable
+	Supply       *sdk.Int // cap of the total of the file, nil if not capped
+	Reserve      sdk.Int  // funded on the source account on top of the claimable coins
+
+	// Whether the supply less the burned and allocated amount is also funded
+	// on the source account
+	KeepUnallocated bool
+}
 
-	// Set mint params
-	genParams.MintParams = minttypes.Params{
-		MintDenom:          genParams.BondDenom,
-		BlockTimeThreshold: 10 * time.Second,
-		InflationSchedules: []minttypes.InflationSchedule{
-			{
-				StartTime: genParams.GenesisTime,
-				EndTime:   genParams.GenesisTime.AddDate(1, 0, 0),
-				Amount:    sdk.NewInt(108_700000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(1, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(2, 0, 0),
-				Amount:    sdk.NewInt(216_100000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(2, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(3, 0, 0),
-				Amount:    sdk.NewInt(151_300000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(3, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(4, 0, 0),
-				Amount:    sdk.NewInt(105_900000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(4, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(5, 0, 0),
-				Amount:    sdk.NewInt(74_100000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(5, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(6, 0, 0),
-				Amount:    sdk.NewInt(51_900000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(6, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(7, 0, 0),
-				Amount:    sdk.NewInt(36_300000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(7, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(8, 0, 0),
-				Amount:    sdk.NewInt(25_400000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(8, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(9, 0, 0),
-				Amount:    sdk.NewInt(17_800000_000000),
-			},
-			{
-				StartTime: genParams.GenesisTime.AddDate(9, 0, 0),
-				EndTime:   genParams.GenesisTime.AddDate(10, 0, 0),
-				Amount:    sdk.NewInt(12_500000_000000),
-			},
-		},
-	}
+// genesisAccountsInput holds the account related values of a profile.
+type genesisAccountsInput struct {
+	Airdrops          []airdropInput
+	Addresses         *AddressPolicy
+	VestingFile       string // empty if the network has no vesting accounts
+	VestingSchedules  VestingSchedules
+	FoundationAddress string
+	FoundationSupply  sdk.Int
+	ValidatorBalances []banktypes.Balance // deducted from the foundation supply
+	Balances          []banktypes.Balance // funded on top of the foundation supply
+	GenTxValidators   []GenTxValidator    // topped up from the foundation supply
+	GenTxFunding      *sdk.Int            // nil to fund the self-delegation of each gentx
+}
 
-	// Set slashing params
-	genParams.SlashingParams = slashingtypes.Params{
-		SignedBlocksWindow:      30000,
-		MinSignedPerWindow:      sdk.MustNewDecFromStr("0.050000000000000000"),
-		DowntimeJailDuration:    60 * time.Second,
-		SlashFractionDoubleSign: sdk.MustNewDecFromStr("0.050000000000000000"),
-		SlashFractionDowntime:   sdk.MustNewDecFromStr("0.000000000000000000"),
-	}
+// setGenesisAccounts sets accounts, balances, claim records and the total supply
+// on genParams. The validator balances and the vesting amount are deducted from
+// the foundation supply. It only depends on genParams and in, and does not
+// modify either input.
+func setGenesisAccounts(genParams *GenesisStates, in genesisAccountsInput) error {
+	foundationAddress := in.FoundationAddress
+	foundationSupply := in.FoundationSupply
 
-	// Set farming params
-	genParams.FarmingParams = farmingtypes.Params{
-		PrivatePlanCreationFee: sdk.NewCoins(sdk.NewInt64Coin(genParams.BondDenom, 100000000)),
-		NextEpochDays:          1,
-		FarmingFeeCollector:    FarmingFeeCollector,
-		DelayedStakingGasFee:   sdk.Gas(100000),
-		MaxNumPrivatePlans:     10000,
+	records := []claimtypes.ClaimRecord{}
+	balances := []banktypes.Balance{}
+	totalAirdrop := sdk.Coins{}
+	genParams.Airdrops = nil
+	for _, airdrop := range in.Airdrops {
+		// Parse claim records, initial genesis balances and totals from the airdrop result file
+		airdropRecords, airdropBalances, result, err := parseClaimRecords(genParams.BondDenom, airdrop, in.Addresses)
+		if err != nil {
+			return err
+		}
+		records = append(records, airdropRecords...)
+		balances = append(balances, airdropBalances...)
+
+		// Set source account balance, funding the claimable coins and the reserve
+		if source := result.Claimable.Add(result.Reserve); source.IsPositive() {
+			balances = append(balances, banktypes.Balance{
+				Address: airdrop.Airdrop.SourceAddress,
+				Coins:   sdk.NewCoins(source),
+			})
+		}
+		totalAirdrop = totalAirdrop.Add(result.Supply())
+		genParams.Airdrops = append(genParams.Airdrops, result)
 	}
 
-	// Set liquidstaking params
-	genParams.LiquidStakingParams = liquidstakingtypes.Params{
-		LiquidBondDenom: LiquidBondDenom,
-		WhitelistedValidators: []liquidstakingtypes.WhitelistedValidator{
-			{
-				ValidatorAddress: "crevaloper1n3mhyp9fvcmuu8l0q8qvjy07x0rql8q4ep74jz",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper17muws0zgrd0vzh37guea7960ym7aqf2j9v6l7s",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper1ls9w867xu0q5zjze5vrakfa2zluahtv44gwn7y",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper10rdgqczxyp69x9llq62cc3xs4w8w0k7p42x9jq",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper1dad8evf6vw72seljuzhjgurq48egaqfndvq38v",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper1zuucyy5v49lwnrdupqqafqdu29qy6wgnadwkuu",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper14lultfckehtszvzw4ehu0apvsr77afvy35naks",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper1qvdyzetkqq6rt4xu234xpvee5wt45a75rt2afe",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper18zvtvhzrqq5ny2jpmlc6new9k4c4uzzh6tcfpt",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper1pxexdsms050v35zu0vc07dk4ml647lsrjff52g",
-				TargetWeight:     sdk.NewInt(10),
-			},
-			{
-				ValidatorAddress: "crevaloper130mdu9a0etmeuw52qfxk73pn0ga6gawk9cyq6a",
-				TargetWeight:     sdk.NewInt(10),
-			},
-		},
-		UnstakeFeeRate:         sdk.MustNewDecFromStr("0.000000000000000000"),
-		MinLiquidStakingAmount: sdk.NewInt(1000000),
-	}
+	// Recipients of several airdrops get a single balance
+	balances = sumBalances(balances)
 
-	// Set liquidity params
-	genParams.LiquidityParams = liquiditytypes.Params{
-		BatchSize:                1,
-		TickPrecision:            3,
-		FeeCollectorAddress:      LiquidityFeeCollectorAddress,
-		DustCollectorAddress:     LiquidityDustCollectorAddress,
-		MinInitialPoolCoinSupply: sdk.NewInt(1_000000_000000),
-		PairCreationFee:          sdk.NewCoins(sdk.NewInt64Coin(genParams.BondDenom, 100_000_000)),
-		PoolCreationFee:          sdk.NewCoins(sdk.NewInt64Coin(genParams.BondDenom, 100_000_000)),
-		MinInitialDepositAmount:  sdk.NewInt(1000000),
-		DepositExtraGas:          sdk.Gas(60000),
-		WithdrawExtraGas:         sdk.Gas(64000),
-		OrderExtraGas:            sdk.Gas(37000),
-		MaxPriceLimitRatio:       sdk.MustNewDecFromStr("0.100000000000000000"),
-		MaxOrderLifespan:         86400 * time.Second,
-		SwapFeeRate:              sdk.MustNewDecFromStr("0.000000000000000000"),
-		WithdrawFeeRate:          sdk.MustNewDecFromStr("0.000000000000000000"),
+	// Parse and create vesting accounts info
+	totalVesting := sdk.Coins{}
+	vestingAccsMap := map[string]VestingGenesisAccount{}
+	vestingAccs := []VestingGenesisAccount{}
+	if in.VestingFile != "" {
+		var err error
+		totalVesting, vestingAccsMap, vestingAccs, genParams.VestingFlagged, err = ParseVestingAccounts(in.VestingFile, genParams.BondDenom, genParams.GenesisTime, in.VestingSchedules, in.Addresses)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Set gov params
-	genParams.GovParams = govtypes.Params{
-		DepositParams: govtypes.DepositParams{
-			MinDeposit: sdk.NewCoins(
-				sdk.NewInt64Coin(genParams.BondDenom, 500000000),
-			),
-			MaxDepositPeriod: 432000 * time.Second, // 5 days
-		},
-		VotingParams: govtypes.VotingParams{
-			VotingPeriod: 432000 * time.Second,
-		},
-		TallyParams: govtypes.TallyParams{
-			Quorum:        sdk.MustNewDecFromStr("0.400000000000000000"),
-			Threshold:     sdk.MustNewDecFromStr("0.500000000000000000"),
-			VetoThreshold: sdk.MustNewDecFromStr("0.334000000000000000"),
-		},
+	// Add accounts
+	newBalances, totalValidatorBalances, err := addValidatorBalances(in.ValidatorBalances)
+	if err != nil {
+		return fmt.Errorf("invalid validator balances: %w", err)
 	}
+	balances = append(balances, newBalances...)
 
-	// Set budget params
-	genParams.BudgetParams = budgettypes.Params{
-		EpochBlocks: 1,
-		Budgets: []budgettypes.Budget{
-			{
-				Name:               "budget-ecosystem-incentive",
-				Rate:               sdk.MustNewDecFromStr("0.662500000000000000"),
-				SourceAddress:      InflationFeeCollector,
-				DestinationAddress: EcosystemIncentive,
-				StartTime:          genParams.GenesisTime,
-				EndTime:            genParams.GenesisTime.AddDate(10, 0, 0),
-			},
-			{
-				Name:               "budget-dev-team",
-				Rate:               sdk.MustNewDecFromStr("0.250000000000000000"),
-				SourceAddress:      InflationFeeCollector,
-				DestinationAddress: DevTeamAddress,
-				StartTime:          genParams.GenesisTime,
-				EndTime:            genParams.GenesisTime.AddDate(10, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-lp-1",
-				Rate:               sdk.MustNewDecFromStr("0.500000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveLP,
-				StartTime:          genParams.GenesisTime,
-				EndTime:            genParams.GenesisTime.AddDate(1, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-mm-1",
-				Rate:               sdk.MustNewDecFromStr("0.300000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveMM,
-				StartTime:          genParams.GenesisTime,
-				EndTime:            genParams.GenesisTime.AddDate(1, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-boost-1",
-				Rate:               sdk.MustNewDecFromStr("0.200000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveBoost,
-				StartTime:          genParams.GenesisTime,
-				EndTime:            genParams.GenesisTime.AddDate(1, 0, 0),
-			},
-
-			{
-				Name:               "budget-ecosystem-incentive-lp-2",
-				Rate:               sdk.MustNewDecFromStr("0.200000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveLP,
-				StartTime:          genParams.GenesisTime.AddDate(1, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(2, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-mm-2",
-				Rate:               sdk.MustNewDecFromStr("0.300000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveMM,
-				StartTime:          genParams.GenesisTime.AddDate(1, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(2, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-boost-2",
-				Rate:               sdk.MustNewDecFromStr("0.500000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveBoost,
-				StartTime:          genParams.GenesisTime.AddDate(1, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(2, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-lp-3-10",
-				Rate:               sdk.MustNewDecFromStr("0.100000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveLP,
-				StartTime:          genParams.GenesisTime.AddDate(2, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(10, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-mm-3-10",
-				Rate:               sdk.MustNewDecFromStr("0.300000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveMM,
-				StartTime:          genParams.GenesisTime.AddDate(2, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(10, 0, 0),
-			},
-			{
-				Name:               "budget-ecosystem-incentive-boost-3-10",
-				Rate:               sdk.MustNewDecFromStr("0.600000000000000000"),
-				SourceAddress:      EcosystemIncentive,
-				DestinationAddress: EcosystemIncentiveBoost,
-				StartTime:          genParams.GenesisTime.AddDate(2, 0, 0),
-				EndTime:            genParams.GenesisTime.AddDate(10, 0, 0),
-			},
-		},
+	// Add balances funded outside of the foundation supply such as a testnet faucet
+	otherBalances, totalOtherBalances, err := addValidatorBalances(in.Balances)
+	if err != nil {
+		return fmt.Errorf("invalid balances: %w", err)
 	}
+	balances = append(balances, otherBalances...)
 
-	// Set claim genesis states
-	airdrop := claimtypes.Airdrop{
-		Id:            1,
-		SourceAddress: AirdropSourceAddress, // airdrop source address
-		Conditions: []claimtypes.ConditionType{
-			claimtypes.ConditionTypeDeposit,
-			claimtypes.ConditionTypeSwap,
-			claimtypes.ConditionTypeLiquidStake,
-			claimtypes.ConditionTypeVote,
-		},
-		StartTime: genParams.GenesisTime,
-		EndTime:   genParams.GenesisTime.AddDate(0, 6, 0),
+	// Top up the accounts of gentx validators, including airdrop recipients
+	balances, totalTopUp, err := topUpValidatorBalances(balances, vestingAccsMap, in.GenTxValidators, in.GenTxFunding, genParams.BondDenom)
+	if err != nil {
+		return err
 	}
-	genParams.ClaimGenesisState.Airdrops = []claimtypes.Airdrop{airdrop}
-
-	// Parse claim records, balances, and total initial genesis coin from the airdrop result file
-	records, balances, totalInitialGenesisCoin := parseClaimRecords(genParams)
-
-	// Deduct 20% initial airdrop amount
-	dexDropSupply := genParams.DEXdropSupply.Sub(totalInitialGenesisCoin)
-
-	// Set source account balances
-	balances = append(balances, banktypes.Balance{
-		Address: airdrop.SourceAddress,
-		Coins:   sdk.NewCoins(dexDropSupply.Add(genParams.BoostdropSupply)), // DEXDropSupply + BoostDropSupply
-	})
-
-	// Add accounts
-	newBalances, totalValidatorBalances := addValidatorBalances()
-	balances = append(balances, newBalances...)
+	totalValidatorBalances = totalValidatorBalances.Add(totalTopUp...)
 
 	// Sub validator amount from foundation
-	FoundationSupply = FoundationSupply.Sub(totalValidatorBalances.AmountOf(BondDenom))
-	// Parse and create vesting accounts info
-	totalVestingAmt, vestingAccsMap, vestingAccs := ParseVestingAccounts(VestingFilePath)
+	foundationSupply = foundationSupply.Sub(totalValidatorBalances.AmountOf(genParams.BondDenom))
+
+	totalVestingAmt := totalVesting.AmountOf(genParams.BondDenom)
+	if foundationSupply.LT(totalVestingAmt) {
+		return fmt.Errorf("foundation supply %s is less than the validator and vesting amount", in.FoundationSupply)
+	}
 
 	// Sub vesting amount from foundation
-	FoundationSupply = FoundationSupply.Sub(totalVestingAmt)
+	foundationSupply = foundationSupply.Sub(totalVestingAmt)
 
 	// Add Foundation balance
-	balances = append(balances, banktypes.Balance{Address: FoundationAddress,
-		Coins: sdk.NewCoins(sdk.NewCoin(genParams.BondDenom, FoundationSupply)), // 100mil - validator amount - vesting amount
+	balances = append(balances, banktypes.Balance{Address: foundationAddress,
+		Coins: sdk.NewCoins(sdk.NewCoin(genParams.BondDenom, foundationSupply)), // 100mil - validator amount - vesting amount
 	})
 
 	// Add genesis accounts
@@ -421,9 +150,9 @@ func MainnetGenesisStates() *GenesisStates {
 	balancesMap := map[string]banktypes.Balance{}
 
 	// Add Foundation as 1st account
-	FoundationAcc, err := sdk.AccAddressFromBech32(FoundationAddress)
+	FoundationAcc, err := sdk.AccAddressFromBech32(foundationAddress)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("invalid foundation address %s: %w", foundationAddress, err)
 	}
 	genAccount := authtypes.NewBaseAccount(FoundationAcc, nil, 0, 0)
 	genAccounts = append(genAccounts, genAccount)
@@ -434,9 +163,8 @@ func MainnetGenesisStates() *GenesisStates {
 
 		// add vesting balance on existing account
 		if vestingAcc, ok := vestingAccsMap[balance.GetAddress().String()]; ok {
-			fmt.Println("added vesting balance on existing account", balance.GetAddress().String(), balances[i].Coins, vestingAcc.OriginalVesting, balances[i].Coins.Add(vestingAcc.OriginalVesting...))
-			balances[i].Coins = balances[i].Coins.Add(vestingAcc.OriginalVesting...)
-		} else if balance.GetAddress().String() != FoundationAddress {
+			balances[i].Coins = balances[i].Coins.Add(vestingAcc.GetOriginalVesting()...)
+		} else if balance.GetAddress().String() != foundationAddress {
 			// add genAccount except vesting accounts
 			genAccount := authtypes.NewBaseAccount(balance.GetAddress(), nil, 0, 0)
 			genAccounts = append(genAccounts, genAccount)
@@ -448,35 +176,11 @@ func MainnetGenesisStates() *GenesisStates {
 		// add balance for new vesting accounts
 		if _, ok := balancesMap[vestingAcc.GetAddress().String()]; !ok {
 			vestingAccsBalances = append(vestingAccsBalances, banktypes.Balance{
-				Address: vestingAcc.Address,
-				Coins:   vestingAcc.OriginalVesting,
+				Address: vestingAcc.GetAddress().String(),
+				Coins:   vestingAcc.GetOriginalVesting(),
 			})
 		}
 
-		first := vestingAcc.VestingPeriods[0].Amount
-		second := sdk.Coins{}
-		for _, period := range vestingAcc.VestingPeriods[1:13] {
-			second = second.Add(period.Amount...)
-		}
-		third := sdk.Coins{}
-		for _, period := range vestingAcc.VestingPeriods[13:25] {
-			third = third.Add(period.Amount...)
-		}
-
-		_, converted, err := bech32.DecodeAndConvert(vestingAcc.Address)
-		if err != nil {
-			panic(err)
-		}
-
-		vestingCosmosAddr, err := bech32.ConvertAndEncode("cosmos", converted)
-		if err != nil {
-			panic(err)
-		}
-		fmt.Println(vestingCosmosAddr, vestingAcc.OriginalVesting, first, second, third)
-		if !first.Add(second...).Add(third...).IsEqual(vestingAcc.OriginalVesting) {
-			panic("total not equal")
-		}
-
 		genAccounts = append(genAccounts, vestingAcc)
 	}
 	balances = append(balances, vestingAccsBalances...)
@@ -484,7 +188,7 @@ func MainnetGenesisStates() *GenesisStates {
 	// Verify genesis accounts
 	for _, genAccount := range genAccounts {
 		if err := genAccount.Validate(); err != nil {
-			panic(fmt.Sprintf("failed to validate genesis account: %s", err.Error()))
+			return fmt.Errorf("failed to validate genesis account %s: %w", genAccount.GetAddress(), err)
 		}
 	}
 
@@ -492,7 +196,7 @@ func MainnetGenesisStates() *GenesisStates {
 
 	genAccs, err := authtypes.PackAccounts(genAccounts)
 	if err != nil {
-		panic(fmt.Errorf("failed to convert accounts into any's: %w", err))
+		return fmt.Errorf("failed to convert accounts into any's: %w", err)
 	}
 	genParams.AuthGenesisState.Accounts = genAccs
 
@@ -501,178 +205,244 @@ func MainnetGenesisStates() *GenesisStates {
 	genParams.BankGenesisStates.Balances = balances
 
 	// Set supply genesis states
-	// Total supply = DEXDropSupply + BoostDropSupply + Foundation + ValidatorBalances + TotalVestingAmount
-	genParams.BankGenesisStates.Supply = sdk.NewCoins(
-		genParams.DEXdropSupply.Add(genParams.BoostdropSupply)).
-		Add(sdk.NewCoin(BondDenom, FoundationSupply)).
-		Add(totalValidatorBalances...).Add(sdk.NewCoin(BondDenom, totalVestingAmt))
-
-	fmt.Println("DEXdropSupply :", genParams.DEXdropSupply)
-	fmt.Println("BoostdropSupply :", genParams.BoostdropSupply)
-	fmt.Println("FoundationSupply :", FoundationSupply)
-	fmt.Println("ValidatorBalances :", totalValidatorBalances)
-	fmt.Println("totalVestingAmt :", totalVestingAmt)
-	fmt.Println("len(vestingAccs) :", len(vestingAccs))
-	fmt.Println("TotalSupply :", genParams.BankGenesisStates.Supply)
-	return genParams
+	// Total supply = Airdrops + Foundation + ValidatorBalances + TotalVestingAmount + OtherBalances
+	genParams.BankGenesisStates.Supply = sdk.NewCoins(totalAirdrop...).
+		Add(sdk.NewCoin(genParams.BondDenom, foundationSupply)).
+		Add(totalValidatorBalances...).Add(totalVesting...).
+		Add(totalOtherBalances...)
+
+	genParams.FoundationSupply = sdk.NewCoin(genParams.BondDenom, foundationSupply)
+	genParams.ValidatorSupply = totalValidatorBalances
+	genParams.VestingSupply = totalVesting
+	genParams.OtherSupply = totalOtherBalances
+	genParams.NumVestingAccounts = len(vestingAccs)
+	return nil
 }
 
-func addValidatorBalances() ([]banktypes.Balance, sdk.Coins) {
-	balances := []banktypes.Balance{
-		//{
-		//	Address: "cre1n3mhyp9fvcmuu8l0q8qvjy07x0rql8q4m476lg", // already balance exist (airdrop recipient)
-		//	Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		//},
-		{
-			Address: "cre17muws0zgrd0vzh37guea7960ym7aqf2j8c6sn6",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre1ls9w867xu0q5zjze5vrakfa2zluahtv4huwunw",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre10rdgqczxyp69x9llq62cc3xs4w8w0k7ph7x2l2",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre1dad8evf6vw72seljuzhjgurq48egaqfn0cq72x",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre1zuucyy5v49lwnrdupqqafqdu29qy6wgnlewe3k",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		//{
-		//	Address: "cre14lultfckehtszvzw4ehu0apvsr77afvynqnjm6", // already balance exist (airdrop recipient)
-		//	Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		//},
-		{
-			Address: "cre1qvdyzetkqq6rt4xu234xpvee5wt45a75pl2jyn",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre18zvtvhzrqq5ny2jpmlc6new9k4c4uzzhclcxvp",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		{
-			Address: "cre1pxexdsms050v35zu0vc07dk4ml647lsrsafm8z",
-			Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		},
-		//{
-		//	Address: "cre130mdu9a0etmeuw52qfxk73pn0ga6gawk8vy0hh", // already balance exist (airdrop recipient)
-		//	Coins:   sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 1_000_000)),
-		//},
-	}
-
+// addValidatorBalances validates the given balances and returns them with their total amount.
+func addValidatorBalances(balances []banktypes.Balance) ([]banktypes.Balance, sdk.Coins, error) {
 	totalValidatorAmt := sdk.Coins{}
 	for _, balance := range balances {
+		if _, err := sdk.AccAddressFromBech32(balance.Address); err != nil {
+			return nil, nil, fmt.Errorf("invalid address %s: %w", balance.Address, err)
+		}
+		if err := balance.Coins.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid coins %s of %s: %w", balance.Coins, balance.Address, err)
+		}
 		totalValidatorAmt = totalValidatorAmt.Add(balance.Coins...)
 	}
 
-	return balances, totalValidatorAmt
+	return balances, totalValidatorAmt, nil
 }
 
-func parseClaimRecords(genParams *GenesisStates) ([]claimtypes.ClaimRecord, []banktypes.Balance, sdk.Coin) {
-	results, err := readCSVFile(filePath)
-	if err != nil {
-		panic(fmt.Sprintf("failed to read csv file %s", filePath))
+// sumBalances adds up the balances of the same address, keeping the position
+// of the first one.
+func sumBalances(balances []banktypes.Balance) []banktypes.Balance {
+	summed := []banktypes.Balance{}
+	index := map[string]int{}
+	for _, balance := range balances {
+		if i, ok := index[balance.Address]; ok {
+			summed[i].Coins = summed[i].Coins.Add(balance.Coins...)
+			continue
+		}
+		index[balance.Address] = len(summed)
+		summed = append(summed, balance)
 	}
+	return summed
+}
 
-	totalInitialGenesisAmt := sdk.ZeroInt()
-	records := []claimtypes.ClaimRecord{}
-	balances := []banktypes.Balance{}
+// parseClaimRecords parses the airdrop result file of address,amount rows into
+// the claim records and the initial genesis balances of the airdrop. The rows
+// are adjusted by the policy first. The returned result holds the totals to
+// fund and the adjustments, starting with the rows whose address conversion is
+// flagged by addresses.
+func parseClaimRecords(denom string, airdrop airdropInput, addresses *AddressPolicy) ([]claimtypes.ClaimRecord, []banktypes.Balance, AirdropResult, error) {
+	filePath := airdrop.File
+	results, err := readCSVFile(filePath, 2)
+	if err != nil {
+		return nil, nil, AirdropResult{}, err
+	}
 
+	rows := []AirdropRow{}
+	flagged := []AirdropAdjustment{}
 	for i, r := range results {
 		if i == 0 {
 			continue
 		}
 
-		recipientAddr := r[0]
-		dexClaimableAmt, ok := sdk.NewIntFromString(r[1])
-		if !ok {
-			panic("failed NewIntFromString for dexClaimableAmt")
-		}
-
 		// Convert bech32 address prefix
-		_, converted, err := bech32.DecodeAndConvert(recipientAddr)
+		recipientAddr, reason, err := addresses.Convert(r[0])
 		if err != nil {
-			panic(err)
+			return nil, nil, AirdropResult{}, newCSVError(filePath, results, i, 0, err)
 		}
-
-		targetPrefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
-		recipientAddr, err = bech32.ConvertAndEncode(targetPrefix, converted)
+		dexClaimableAmt, err := parseAmount(r[1])
 		if err != nil {
-			panic(err)
+			return nil, nil, AirdropResult{}, newCSVError(filePath, results, i, 1, err)
 		}
 
 		// Skip the zero amount
 		if dexClaimableAmt.IsZero() {
 			continue
 		}
+		row := AirdropRow{Row: i + 1, Address: recipientAddr, Amount: dexClaimableAmt}
+		if reason != "" {
+			flagged = append(flagged, AirdropAdjustment{row.Row, row.Address, AirdropRuleFlagged, reason + ", converted from " + r[0], row.Amount, row.Amount})
+		}
+		rows = append(rows, row)
+	}
 
-		initialGenesisAmt := dexClaimableAmt.Quo(sdk.NewInt(5))
+	policy := airdrop.Policy
+	if policy == nil {
+		policy = &AirdropPolicy{}
+	}
+	rows, adjustments, burned := policy.Apply(rows)
+	adjustments = append(flagged, adjustments...)
+
+	totalAmt := sdk.ZeroInt()
+	for _, row := range rows {
+		totalAmt = totalAmt.Add(row.Amount)
+	}
+	if airdrop.Supply != nil {
+		if available := airdrop.Supply.Sub(burned); totalAmt.GT(available) {
+			return nil, nil, AirdropResult{}, fmt.Errorf("airdrop file %s allocates %s, more than the supply %s of airdrop %d less the burned %s",
+				filePath, totalAmt, airdrop.Supply, airdrop.Airdrop.Id, burned)
+		}
+	}
+
+	airdropId := airdrop.Airdrop.Id
+	totalInitialGenesisAmt := sdk.ZeroInt()
+	dust := sdk.ZeroDec()
+	records := []claimtypes.ClaimRecord{}
+	balances := []banktypes.Balance{}
+
+	for _, row := range rows {
+		recipientAddr, dexClaimableAmt := row.Address, row.Amount
+
+		// The genesis part is rounded down, its fraction is claimable instead
+		genesisDec := airdrop.GenesisRatio.MulInt(dexClaimableAmt)
+		initialGenesisAmt := genesisDec.TruncateInt()
 		initialClaimableAmt := dexClaimableAmt.Sub(initialGenesisAmt)
+		dust = dust.Add(genesisDec.Sub(initialGenesisAmt.ToDec()))
 
-		// 20% is set in genesis
-		balance := banktypes.Balance{
-			Address: recipientAddr,
-			Coins:   sdk.NewCoins(sdk.NewCoin(genParams.BondDenom, initialGenesisAmt)),
+		// The genesis part is set in genesis
+		if initialGenesisAmt.IsPositive() {
+			balances = append(balances, banktypes.Balance{
+				Address: recipientAddr,
+				Coins:   sdk.NewCoins(sdk.NewCoin(denom, initialGenesisAmt)),
+			})
 		}
-		balances = append(balances, balance)
 
-		// 80% is set in claim record
-		records = append(records, claimtypes.ClaimRecord{
-			AirdropId:             1,
-			Recipient:             recipientAddr,
-			InitialClaimableCoins: sdk.NewCoins(sdk.NewCoin(genParams.BondDenom, initialClaimableAmt)),
-			ClaimableCoins:        sdk.NewCoins(sdk.NewCoin(genParams.BondDenom, initialClaimableAmt)),
-		})
+		// The rest is set in claim record
+		if initialClaimableAmt.IsPositive() {
+			records = append(records, claimtypes.ClaimRecord{
+				AirdropId:             airdropId,
+				Recipient:             recipientAddr,
+				InitialClaimableCoins: sdk.NewCoins(sdk.NewCoin(denom, initialClaimableAmt)),
+				ClaimableCoins:        sdk.NewCoins(sdk.NewCoin(denom, initialClaimableAmt)),
+			})
+		}
 
 		// Track the total initial genesis amount
 		totalInitialGenesisAmt = totalInitialGenesisAmt.Add(initialGenesisAmt)
 	}
 
-	totalInitialGenesisCoin := sdk.NewCoin(genParams.BondDenom, totalInitialGenesisAmt)
+	result := AirdropResult{
+		Name:          airdrop.Name,
+		Id:            airdropId,
+		SourceAddress: airdrop.Airdrop.SourceAddress,
+		Recipients:    len(rows),
+		Genesis:       sdk.NewCoin(denom, totalInitialGenesisAmt),
+		Claimable:     sdk.NewCoin(denom, totalAmt.Sub(totalInitialGenesisAmt)),
+		Reserve:       sdk.NewCoin(denom, airdrop.Reserve),
+		Burned:        sdk.NewCoin(denom, burned),
+		Dust:          sdk.NewDecCoinFromDec(denom, dust),
+		Adjustments:   adjustments,
+	}
+	if airdrop.KeepUnallocated {
+		result.Reserve = result.Reserve.AddAmount(airdrop.Supply.Sub(burned).Sub(totalAmt))
+	}
+	return records, balances, result, nil
+}
+
+// Columns of the vesting file. The first two columns are address and
+// vesting_total_amounts; the optional columns are recognized by their header.
+const (
+	vestingColumnSchedule = "schedule" // schedule template, default "default"
+	vestingColumnStart    = "start"    // RFC3339 time or offset from the genesis time, default 0
+	vestingColumnDenom    = "denom"    // default the bond denom
+	vestingColumnType     = "type"     // account type, default periodic
+)
+
+// Vesting account types of the vesting file.
+const (
+	VestingTypePeriodic        = "periodic"
+	VestingTypeContinuous      = "continuous"
+	VestingTypeDelayed         = "delayed"
+	VestingTypePermanentLocked = "permanent-locked"
+)
 
-	return records, balances, totalInitialGenesisCoin
+// VestingGenesisAccount is a vesting account that can be added to the genesis state.
+type VestingGenesisAccount interface {
+	authtypes.GenesisAccount
+	vestexported.VestingAccount
 }
 
-func ParseVestingAccounts(filePath string) (sdk.Int, map[string]*authvesting.PeriodicVestingAccount, []*authvesting.PeriodicVestingAccount) {
-	vestingAccs := []*authvesting.PeriodicVestingAccount{}
-	vestingAccMap := make(map[string]*authvesting.PeriodicVestingAccount)
-	results, err := readCSVFile(filePath)
+// ParseVestingAccounts parses the vesting file into vesting accounts.
+//
+// Each row has an address and an integer amount, optionally followed by the
+// schedule, start, denom and type columns. Empty or missing optional values
+// default to a periodic account of denom starting at startTime following the
+// default schedule, so two column files keep working. Periodic accounts follow
+// the periods of the schedule, continuous accounts vest linearly and delayed
+// accounts unlock at once at the end of the schedule. Permanent locked accounts
+// ignore the schedule and the start. Addresses are converted by addresses, and
+// the rows whose conversion is flagged are returned along with the accounts.
+func ParseVestingAccounts(filePath string, denom string, startTime time.Time, schedules VestingSchedules, addresses *AddressPolicy) (sdk.Coins, map[string]VestingGenesisAccount, []VestingGenesisAccount, []FlaggedAddress, error) {
+	vestingAccs := []VestingGenesisAccount{}
+	vestingAccMap := make(map[string]VestingGenesisAccount)
+	flagged := []FlaggedAddress{}
+	results, err := readCSVFile(filePath, 2)
 	if err != nil {
-		panic(fmt.Sprintf("failed to read csv file %s", filePath))
+		return nil, nil, nil, nil, err
+	}
+
+	columns := map[string]int{}
+	for col, name := range results[0][2:] {
+		switch name = strings.TrimSpace(name); name {
+		case vestingColumnSchedule, vestingColumnStart, vestingColumnDenom, vestingColumnType:
+			columns[name] = col + 2
+		default:
+			return nil, nil, nil, nil, newCSVError(filePath, results, 0, col+2, fmt.Errorf("unknown column"))
+		}
+	}
+	// cell returns the value and the column index of an optional column
+	cell := func(r []string, name string) (string, int) {
+		col, ok := columns[name]
+		if !ok || col >= len(r) {
+			return "", col
+		}
+		return strings.TrimSpace(r[col]), col
 	}
 
-	totalVestingAmt := sdk.ZeroInt()
+	totalVesting := sdk.Coins{}
 
 	for i, r := range results {
 		if i == 0 {
 			continue
 		}
 
-		recipientAddr := r[0]
-		vestingAmt, ok := sdk.NewIntFromString(r[1])
-		if !ok {
-			panic("failed NewIntFromString for vestingAmt")
-		}
-
 		// Convert bech32 address prefix
-		_, converted, err := bech32.DecodeAndConvert(recipientAddr)
+		recipientAddr, reason, err := addresses.Convert(r[0])
 		if err != nil {
-			panic(err)
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, 0, err)
 		}
-
-		targetPrefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
-		recipientAddr, err = bech32.ConvertAndEncode(targetPrefix, converted)
+		recipientAcc, err := sdk.AccAddressFromBech32(recipientAddr)
 		if err != nil {
-			panic(err)
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, 0, err)
 		}
-		recipientAcc, err := sdk.AccAddressFromBech32(recipientAddr)
+		vestingAmt, err := parseAmount(r[1])
 		if err != nil {
-			panic(err)
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, 1, err)
 		}
 
 		// Skip the zero amount
@@ -680,77 +450,66 @@ func ParseVestingAccounts(filePath string) (sdk.Int, map[string]*authvesting.Per
 			continue
 		}
 
-		baseAcc := authtypes.NewBaseAccount(recipientAcc, nil, 0, 0)
-		periodVestingAcc := authvesting.NewPeriodicVestingAccount(baseAcc, sdk.NewCoins(sdk.NewCoin(BondDenom, vestingAmt)), GenesisTimeUnix, CalcVestingPeriod(vestingAmt))
-		vestingAccMap[periodVestingAcc.Address] = periodVestingAcc
-		vestingAccs = append(vestingAccs, periodVestingAcc)
-
-		// Track the total vesting amount
-		totalVestingAmt = totalVestingAmt.Add(vestingAmt)
-	}
-	return totalVestingAmt, vestingAccMap, vestingAccs
-}
-
-var (
-	FirstYearCliff              = int64(60 * 60 * 24 * 365)                              // 31,536,000 1year
-	SecondThirdYearMonthlyCliff = int64(60 * 60 * 24 * 365 / 12)                         // 2,628,000 1month
-	TotalVestingLength          = FirstYearCliff + SecondThirdYearMonthlyCliff*int64(24) // 94,608,000 3year
-	FirstYearRatio              = sdk.MustNewDecFromStr("0.34")
-	SecondYearRatio             = sdk.MustNewDecFromStr("0.34")
-	ThirdYearRatio              = sdk.MustNewDecFromStr("0.32")
-	TotalCliff                  = 25
-)
-
-func CalcVestingPeriod(totalVestingAmount sdk.Int) authvesting.Periods {
-	periods := authvesting.Periods{}
-
-	firstYearVestingAmount := totalVestingAmount.ToDec().MulTruncate(FirstYearRatio).TruncateInt()
-	secondYearMonthlyVestingAmount := totalVestingAmount.ToDec().MulTruncate(SecondYearRatio).QuoInt64(12).TruncateInt()
-	thirdYearMonthlyVestingAmount := totalVestingAmount.ToDec().MulTruncate(ThirdYearRatio).QuoInt64(12).TruncateInt()
-	crumb := totalVestingAmount.Sub(firstYearVestingAmount).Sub(secondYearMonthlyVestingAmount.MulRaw(12)).Sub(thirdYearMonthlyVestingAmount.MulRaw(12))
-	firstYearVestingAmount = firstYearVestingAmount.Add(crumb)
-	//firstYearVestingAmount := totalVestingAmount.Sub(secondYearMonthlyVestingAmount.MulRaw(12)).Sub(thirdYearMonthlyVestingAmount.MulRaw(12))
-
-	// 1st year
-	periods = append(periods, authvesting.Period{
-		Length: FirstYearCliff,
-		Amount: sdk.NewCoins(sdk.NewCoin(BondDenom, firstYearVestingAmount)),
-	})
-
-	// 2nd year
-	for i := 0; i < 12; i++ {
-		periods = append(periods, authvesting.Period{
-			Length: SecondThirdYearMonthlyCliff,
-			Amount: sdk.NewCoins(sdk.NewCoin(BondDenom, secondYearMonthlyVestingAmount)),
-		})
-	}
+		if _, ok := vestingAccMap[recipientAddr]; ok {
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, 0, fmt.Errorf("duplicate address"))
+		}
 
-	// 3rd year
-	for i := 0; i < 12; i++ {
-		periods = append(periods, authvesting.Period{
-			Length: SecondThirdYearMonthlyCliff,
-			Amount: sdk.NewCoins(sdk.NewCoin(BondDenom, thirdYearMonthlyVestingAmount)),
-		})
-	}
+		scheduleName, scheduleCol := cell(r, vestingColumnSchedule)
+		if scheduleName == "" {
+			scheduleName = DefaultVestingScheduleName
+		}
+		schedule, err := schedules.Get(scheduleName)
+		if err != nil {
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, scheduleCol, err)
+		}
 
-	if len(periods) != TotalCliff {
-		panic("error vesting periods number")
-	}
+		accStartTime := startTime
+		if s, col := cell(r, vestingColumnStart); s != "" {
+			accStartTime, err = ParseOffsetTime(startTime, s)
+			if err != nil {
+				return nil, nil, nil, nil, newCSVError(filePath, results, i, col, err)
+			}
+		}
+		endTime := accStartTime.Unix() + schedule.Length()
+
+		accDenom := denom
+		if s, col := cell(r, vestingColumnDenom); s != "" {
+			if err := sdk.ValidateDenom(s); err != nil {
+				return nil, nil, nil, nil, newCSVError(filePath, results, i, col, err)
+			}
+			accDenom = s
+		}
+		originalVesting := sdk.NewCoins(sdk.NewCoin(accDenom, vestingAmt))
 
-	totalLength := int64(0)
-	totalAmount := sdk.ZeroInt()
-	for _, period := range periods {
-		totalLength = totalLength + period.Length
-		totalAmount = totalAmount.Add(period.Amount[0].Amount)
-	}
+		baseAcc := authtypes.NewBaseAccount(recipientAcc, nil, 0, 0)
+		var vestingAcc VestingGenesisAccount
+		switch accType, col := cell(r, vestingColumnType); accType {
+		case "", VestingTypePeriodic:
+			periods, err := schedule.Periods(vestingAmt, accDenom)
+			if err != nil {
+				return nil, nil, nil, nil, newCSVError(filePath, results, i, scheduleCol, err)
+			}
+			vestingAcc = authvesting.NewPeriodicVestingAccount(baseAcc, originalVesting, accStartTime.Unix(), periods)
+		case VestingTypeContinuous:
+			vestingAcc = authvesting.NewContinuousVestingAccount(baseAcc, originalVesting, accStartTime.Unix(), endTime)
+		case VestingTypeDelayed:
+			vestingAcc = authvesting.NewDelayedVestingAccount(baseAcc, originalVesting, endTime)
+		case VestingTypePermanentLocked:
+			vestingAcc = authvesting.NewPermanentLockedAccount(baseAcc, originalVesting)
+		default:
+			return nil, nil, nil, nil, newCSVError(filePath, results, i, col,
+				fmt.Errorf("unknown account type, expected one of %s, %s, %s or %s",
+					VestingTypePeriodic, VestingTypeContinuous, VestingTypeDelayed, VestingTypePermanentLocked))
+		}
 
-	if totalLength != TotalVestingLength {
-		panic("error total vesting length")
-	}
+		vestingAccMap[recipientAddr] = vestingAcc
+		vestingAccs = append(vestingAccs, vestingAcc)
+		if reason != "" {
+			flagged = append(flagged, FlaggedAddress{Row: i + 1, Address: r[0], Converted: recipientAddr, Reason: reason})
+		}
 
-	if !totalAmount.Equal(totalVestingAmount) {
-		fmt.Println(totalAmount, totalVestingAmount)
-		panic("error total vesting amount")
+		// Track the total vesting amount
+		totalVesting = totalVesting.Add(originalVesting...)
 	}
-	return periods
+	return totalVesting, vestingAccMap, vestingAccs, flagged, nil
 }
@@ -1,46 +1,116 @@
 package cmd_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
 )
 
 func TestParseVestingAccounts(t *testing.T) {
+	genesisTime := time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC)
+	schedules := cmd.VestingSchedules{cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule()}
 
-	totalVestingAmt, _, vestingAccs := cmd.ParseVestingAccounts(cmd.VestingFilePathTest)
+	totalVestingAmt, _, vestingAccs, _, err := cmd.ParseVestingAccounts(vestingFilePathTest, bondDenom, genesisTime, schedules, nil)
+	require.NoError(t, err)
 	// 100000000 * 2
-	require.EqualValues(t, sdk.NewInt(200000000), totalVestingAmt)
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(200000000))), totalVestingAmt)
 
 	// vesting amt 100000000
 	vestingAcc := vestingAccs[len(vestingAccs)-1]
 
-	genesisTime := cmd.ParseTime(cmd.GenesisTime)
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000))), vestingAcc.LockedCoins(genesisTime))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestingCoins(genesisTime))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000))), vestingAcc.LockedCoins(genesisTime))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestingCoins(genesisTime))
 	require.True(t, vestingAcc.GetVestedCoins(genesisTime).Empty())
 
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*364)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*364)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*364)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*364)))
 	require.True(t, vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*364)).Empty())
 
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(65999988))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*365)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(65999988))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*365)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(34000012))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(65999988))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*365)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(65999988))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*365)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(34000012))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365)))
 
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(65999988-2833333))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*396)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(65999988-2833333))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*396)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(34000012+2833333))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*396)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(65999988-2833333))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*396)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(65999988-2833333))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*396)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(34000012+2833333))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*396)))
 
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(2666666))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*365*3-1)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(2666666))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*365*3-1)))
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000-2666666))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365*3-1)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(2666666))), vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*365*3-1)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(2666666))), vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*365*3-1)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000-2666666))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365*3-1)))
 
 	require.True(t, vestingAcc.LockedCoins(genesisTime.Add(time.Hour*24*365*3)).Empty())
 	require.True(t, vestingAcc.GetVestingCoins(genesisTime.Add(time.Hour*24*365*3)).Empty())
-	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(cmd.BondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365*3)))
+	require.EqualValues(t, sdk.NewCoins(sdk.NewCoin(bondDenom, sdk.NewInt(100000000))), vestingAcc.GetVestedCoins(genesisTime.Add(time.Hour*24*365*3)))
+}
+
+func TestParseVestingAccountsInvalidRow(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "vesting.csv")
+	content := "address,vesting_total_amounts\n" +
+		"cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,100000000\n" +
+		"cosmos15u8u9zmjlnl98075cadwjgyrejqyfq69mj2hrc,1000x\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+
+	_, _, _, _, err := cmd.ParseVestingAccounts(filePath, bondDenom, time.Now(), cmd.VestingSchedules{cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule()}, nil)
+	var csvErr *cmd.CSVError
+	require.ErrorAs(t, err, &csvErr)
+	require.Equal(t, filePath, csvErr.File)
+	require.Equal(t, 3, csvErr.Row)
+	require.Equal(t, "vesting_total_amounts", csvErr.Column)
+	require.Equal(t, "1000x", csvErr.Value)
+}
+
+func TestParseVestingAccountsColumns(t *testing.T) {
+	genesisTime := time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC)
+	schedules := cmd.VestingSchedules{
+		cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule(),
+		"advisor": {
+			Name:      "advisor",
+			Phases:    []cmd.VestingPhase{{Ratio: sdk.OneDec(), Tranches: 2, Interval: cmd.VestingYear}},
+			Remainder: cmd.RemainderFirst,
+		},
+	}
+
+	filePath := filepath.Join(t.TempDir(), "vesting.csv")
+	content := "address,vesting_total_amounts,schedule,start,denom,type\n" +
+		"cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,100\n" +
+		"cosmos15u8u9zmjlnl98075cadwjgyrejqyfq69mj2hrc,200,advisor,6mo,,continuous\n" +
+		"cosmos1qwyzaspng5ru4kpeh0wg88ng40xpxs056k8lf5,300,advisor,,uatom,delayed\n" +
+		"cosmos10t4874fv0k8xv4kqvu24f9yjkaackl5xzwfpkv,400,,,,permanent-locked\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+
+	total, _, vestingAccs, _, err := cmd.ParseVestingAccounts(filePath, bondDenom, genesisTime, schedules, nil)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 700), sdk.NewInt64Coin("uatom", 300)), total)
+	require.Len(t, vestingAccs, 4)
+
+	require.IsType(t, &authvesting.PeriodicVestingAccount{}, vestingAccs[0])
+	require.Equal(t, genesisTime.Unix(), vestingAccs[0].GetStartTime())
+
+	require.IsType(t, &authvesting.ContinuousVestingAccount{}, vestingAccs[1])
+	start := genesisTime.AddDate(0, 6, 0).Unix()
+	require.Equal(t, start, vestingAccs[1].GetStartTime())
+	require.Equal(t, start+2*cmd.VestingYear, vestingAccs[1].GetEndTime())
+
+	require.IsType(t, &authvesting.DelayedVestingAccount{}, vestingAccs[2])
+	require.Equal(t, genesisTime.Unix()+2*cmd.VestingYear, vestingAccs[2].GetEndTime())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 300)), vestingAccs[2].GetOriginalVesting())
+
+	require.IsType(t, &authvesting.PermanentLockedAccount{}, vestingAccs[3])
+
+	// Unknown schedules and account types are reported with their cell
+	content = "address,vesting_total_amounts,schedule\n" +
+		"cosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,100,team\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+	_, _, _, _, err = cmd.ParseVestingAccounts(filePath, bondDenom, genesisTime, schedules, nil)
+	var csvErr *cmd.CSVError
+	require.ErrorAs(t, err, &csvErr)
+	require.Equal(t, "schedule", csvErr.Column)
+	require.Equal(t, "team", csvErr.Value)
 }
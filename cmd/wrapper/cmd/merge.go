@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// Strategies for accounts that already exist in the genesis file
+// when prepare-genesis is run.
+const (
+	StrategyReplace        = "replace"
+	StrategyMerge          = "merge"
+	StrategyFailOnExisting = "fail-on-existing"
+)
+
+// AccountConflict describes an address that exists both in the genesis file
+// and in the generated genesis states.
+type AccountConflict struct {
+	Address string
+	Reason  string
+}
+
+func (c AccountConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Address, c.Reason)
+}
+
+// MergeExistingAccounts applies strategy to the accounts and balances already in
+// appState, updating the accounts, balances and supply of genParams.
+//
+// With replace the existing accounts are dropped and reported. With merge they
+// are kept; balances of an address present on both sides are added up, and the
+// supply is recomputed from the merged balances. Two vesting accounts at the
+// same address cannot be merged and result in an error. With fail-on-existing
+// any existing account or balance is an error.
+func MergeExistingAccounts(cdc codec.Codec, appState map[string]json.RawMessage, genParams *GenesisStates, strategy string) ([]AccountConflict, error) {
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	existingAccs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	existingBalances := banktypes.GetGenesisStateFromAppState(cdc, appState).Balances
+
+	switch strategy {
+	case StrategyReplace:
+		conflicts := []AccountConflict{}
+		seen := map[string]bool{}
+		for _, acc := range existingAccs {
+			seen[acc.GetAddress().String()] = true
+			conflicts = append(conflicts, AccountConflict{acc.GetAddress().String(), "existing account dropped"})
+		}
+		for _, balance := range existingBalances {
+			if !seen[balance.Address] {
+				conflicts = append(conflicts, AccountConflict{balance.Address, "existing balance dropped"})
+			}
+		}
+		return conflicts, nil
+
+	case StrategyFailOnExisting:
+		if len(existingAccs) == 0 && len(existingBalances) == 0 {
+			return nil, nil
+		}
+		addrs := []string{}
+		for _, acc := range existingAccs {
+			addrs = append(addrs, acc.GetAddress().String())
+		}
+		return nil, fmt.Errorf("genesis file already has %d accounts and %d balances: %s",
+			len(existingAccs), len(existingBalances), strings.Join(addrs, ", "))
+
+	case StrategyMerge:
+		return mergeAccounts(existingAccs, existingBalances, genParams)
+
+	default:
+		return nil, fmt.Errorf("unknown strategy %q, expected one of %s, %s or %s",
+			strategy, StrategyReplace, StrategyMerge, StrategyFailOnExisting)
+	}
+}
+
+func mergeAccounts(existingAccs authtypes.GenesisAccounts, existingBalances []banktypes.Balance, genParams *GenesisStates) ([]AccountConflict, error) {
+	conflicts := []AccountConflict{}
+
+	newAccs, err := authtypes.UnpackAccounts(genParams.AuthGenesisState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	newAccIndex := map[string]int{}
+	for i, acc := range newAccs {
+		newAccIndex[acc.GetAddress().String()] = i
+	}
+
+	// Keep existing accounts unless the generated one is a vesting account
+	accs := authtypes.GenesisAccounts{}
+	for _, acc := range existingAccs {
+		addr := acc.GetAddress().String()
+		i, ok := newAccIndex[addr]
+		if !ok {
+			accs = append(accs, acc)
+			continue
+		}
+
+		_, existingVesting := acc.(vestexported.VestingAccount)
+		_, newVesting := newAccs[i].(vestexported.VestingAccount)
+		switch {
+		case existingVesting && newVesting:
+			return nil, fmt.Errorf("cannot merge vesting accounts at %s", addr)
+		case existingVesting:
+			newAccs[i] = acc
+			conflicts = append(conflicts, AccountConflict{addr, "kept existing vesting account"})
+		case newVesting:
+			conflicts = append(conflicts, AccountConflict{addr, "replaced existing account with vesting account"})
+		default:
+			conflicts = append(conflicts, AccountConflict{addr, "account exists in both"})
+		}
+	}
+	accs = append(accs, newAccs...)
+	accs = authtypes.SanitizeGenesisAccounts(accs)
+
+	// Add up balances
+	balanceIndex := map[string]int{}
+	balances := []banktypes.Balance{}
+	for _, balance := range existingBalances {
+		balanceIndex[balance.Address] = len(balances)
+		balances = append(balances, banktypes.Balance{Address: balance.Address, Coins: balance.Coins})
+	}
+	for _, balance := range genParams.BankGenesisStates.Balances {
+		i, ok := balanceIndex[balance.Address]
+		if !ok {
+			balanceIndex[balance.Address] = len(balances)
+			balances = append(balances, balance)
+			continue
+		}
+		conflicts = append(conflicts, AccountConflict{balance.Address,
+			fmt.Sprintf("balance merged, existing %s + new %s", balances[i].Coins, balance.Coins)})
+		balances[i].Coins = balances[i].Coins.Add(balance.Coins...)
+	}
+	balances = banktypes.SanitizeGenesisBalances(balances)
+
+	supply := sdk.Coins{}
+	for _, balance := range balances {
+		supply = supply.Add(balance.Coins...)
+	}
+
+	genAccs, err := authtypes.PackAccounts(accs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert accounts into any's: %w", err)
+	}
+	genParams.AuthGenesisState.Accounts = genAccs
+	genParams.BankGenesisStates.Balances = balances
+	genParams.BankGenesisStates.Supply = supply
+
+	return conflicts, nil
+}
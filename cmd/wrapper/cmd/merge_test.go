@@ -0,0 +1,47 @@
+package cmd_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	chain "github.com/crescent-network/crescent/app"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestMergeExistingAccounts(t *testing.T) {
+	cdc := newTestClientCtx(t).Codec
+
+	// existing genesis with a validator account added by add-genesis-account
+	addr := sdk.AccAddress(make([]byte, 20))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("utcre", 10_000_000_000))
+	appState := chain.ModuleBasics.DefaultGenesis(cdc)
+	genAccs, err := authtypes.PackAccounts(authtypes.GenesisAccounts{authtypes.NewBaseAccount(addr, nil, 0, 0)})
+	require.NoError(t, err)
+	authGenState := authtypes.DefaultGenesisState()
+	authGenState.Accounts = genAccs
+	appState[authtypes.ModuleName] = cdc.MustMarshalJSON(authGenState)
+	bankGenState := banktypes.DefaultGenesisState()
+	bankGenState.Balances = []banktypes.Balance{{Address: addr.String(), Coins: coins}}
+	bankGenState.Supply = coins
+	appState[banktypes.ModuleName] = cdc.MustMarshalJSON(bankGenState)
+
+	genStates, err := cmd.TestnetGenesisStates()
+	require.NoError(t, err)
+	_, err = cmd.MergeExistingAccounts(cdc, appState, genStates, cmd.StrategyFailOnExisting)
+	require.Error(t, err)
+
+	supply := genStates.BankGenesisStates.Supply
+	conflicts, err := cmd.MergeExistingAccounts(cdc, appState, genStates, cmd.StrategyMerge)
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+	require.Equal(t, supply.Add(coins...), genStates.BankGenesisStates.Supply)
+	require.Len(t, genStates.BankGenesisStates.Balances, len(genStates.AuthGenesisState.Accounts))
+
+	_, err = cmd.MergeExistingAccounts(cdc, appState, genStates, "unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+const flagPolicy = "policy"
+
+// GenTxPolicy is the policy file gentxs are checked against. The chain id, bond
+// denom and consensus key types default to those of the genesis file. Amounts
+// are integer strings (underscores are allowed as digit separators) and rates
+// are decimal strings. Empty bounds are not checked.
+type GenTxPolicy struct {
+	ChainId        string   `yaml:"chain_id" json:"chain_id"`
+	BondDenom      string   `yaml:"bond_denom" json:"bond_denom"`
+	PubKeyTypes    []string `yaml:"pub_key_types" json:"pub_key_types"`
+	SelfDelegation Range    `yaml:"self_delegation" json:"self_delegation"`
+	Commission     struct {
+		Rate          Range `yaml:"rate" json:"rate"`
+		MaxRate       Range `yaml:"max_rate" json:"max_rate"`
+		MaxChangeRate Range `yaml:"max_change_rate" json:"max_change_rate"`
+	} `yaml:"commission" json:"commission"`
+}
+
+// Range is an inclusive range whose bounds are optional.
+type Range struct {
+	Min string `yaml:"min" json:"min"`
+	Max string `yaml:"max" json:"max"`
+}
+
+// GenTxCheck is the result of checking a gentx against the policy.
+type GenTxCheck struct {
+	File      string   `json:"file"`
+	Moniker   string   `json:"moniker"`
+	Validator string   `json:"validator"`
+	Passed    bool     `json:"passed"`
+	Failures  []string `json:"failures"`
+}
+
+func (c *GenTxCheck) fail(format string, a ...interface{}) {
+	c.Failures = append(c.Failures, fmt.Sprintf(format, a...))
+}
+
+// LoadGenTxPolicy reads a gentx policy from a YAML or JSON file.
+func LoadGenTxPolicy(path string) (*GenTxPolicy, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	policy := &GenTxPolicy{}
+	if err := yaml.UnmarshalStrict(bz, policy); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode policy: %w", path, err)
+	}
+	return policy, nil
+}
+
+func ValidateGenTxsCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-gentxs [gentx-dir]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Check the gentxs of a directory against a policy before collecting them",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Check the gentxs of a directory against a policy before collecting them.
+
+Every gentx is checked for:
+  - a single valid MsgCreateValidator signed by the delegator for the chain id
+  - a self-delegation of the bond denom within the policy bounds
+  - commission rate, max rate and max change rate within the policy bounds
+  - a moniker, operator address and consensus key not used by another gentx
+  - a consensus key of a type allowed by the genesis consensus params
+  - a genesis account of the delegator funded with the self-delegation
+
+The policy is a YAML or JSON file given by --%s:
+
+  chain_id: crescent-1
+  bond_denom: ucre
+  self_delegation: { min: 1_000_000, max: 100_000_000 }
+  commission:
+    rate: { min: "0.05", max: "0.2" }
+    max_change_rate: { max: "0.05" }
+
+The gentx directory defaults to config/gentx and the genesis file to the one in
+--home. A pass or fail line is printed per gentx.
+
+Example:
+$ %s validate-gentxs --policy ./gentx-policy.yaml
+$ %s validate-gentxs ./gentxs --policy ./gentx-policy.yaml --output json
+`,
+				flagPolicy,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genTxDir := filepath.Join(serverCtx.Config.RootDir, "config", "gentx")
+			if len(args) == 1 {
+				genTxDir = args[0]
+			}
+
+			policyPath, err := cmd.Flags().GetString(flagPolicy)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+
+			policy := &GenTxPolicy{}
+			if policyPath != "" {
+				policy, err = LoadGenTxPolicy(policyPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(serverCtx.Config.GenesisFile())
+			if err != nil {
+				return fmt.Errorf("failed to read genesis doc from file: %w", err)
+			}
+
+			checks, err := CheckGenTxs(clientCtx, genDoc, genTxDir, policy)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				if err := writeJSON(cmd.OutOrStdout(), checks); err != nil {
+					return err
+				}
+			case "text":
+				writeGenTxChecks(cmd.OutOrStdout(), checks)
+			default:
+				return fmt.Errorf("unknown output format %q, expected text or json", output)
+			}
+
+			failed := 0
+			for _, check := range checks {
+				if !check.Passed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d gentxs failed", failed, len(checks))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagPolicy, "", "YAML or JSON policy file the gentxs are checked against")
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "text", "Output format (text|json)")
+
+	return cmd
+}
+
+// CheckGenTxs checks the gentx json files of dir, in file name order, against
+// policy and the genesis doc the gentxs are collected into.
+func CheckGenTxs(clientCtx client.Context, genDoc *tmtypes.GenesisDoc, dir string, policy *GenTxPolicy) ([]GenTxCheck, error) {
+	cdc := clientCtx.Codec
+
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis state: %w", err)
+	}
+	var stakingGenState stakingtypes.GenesisState
+	if bz, ok := appState[stakingtypes.ModuleName]; ok {
+		if err := cdc.UnmarshalJSON(bz, &stakingGenState); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal staking genesis state: %w", err)
+		}
+	}
+	accs, err := authtypes.UnpackAccounts(authtypes.GetGenesisStateFromAppState(cdc, appState).Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+	accounts := map[string]bool{}
+	for _, acc := range accs {
+		accounts[acc.GetAddress().String()] = true
+	}
+	balances := map[string]sdk.Coins{}
+	for _, balance := range banktypes.GetGenesisStateFromAppState(cdc, appState).Balances {
+		balances[balance.Address] = balances[balance.Address].Add(balance.Coins...)
+	}
+
+	chainID := policy.ChainId
+	if chainID == "" {
+		chainID = genDoc.ChainID
+	}
+	bondDenom := policy.BondDenom
+	if bondDenom == "" {
+		bondDenom = stakingGenState.Params.BondDenom
+	}
+	pubKeyTypes := policy.PubKeyTypes
+	if len(pubKeyTypes) == 0 && genDoc.ConsensusParams != nil {
+		pubKeyTypes = genDoc.ConsensusParams.Validator.PubKeyTypes
+	}
+
+	d := &profileDecoder{}
+	minSelfDelegation := d.optionalInt("self_delegation.min", policy.SelfDelegation.Min)
+	maxSelfDelegation := d.optionalInt("self_delegation.max", policy.SelfDelegation.Max)
+	rateBounds := []struct {
+		name     string
+		min, max *sdk.Dec
+		rate     func(stakingtypes.CommissionRates) sdk.Dec
+	}{
+		{"commission rate", d.optionalDec("commission.rate.min", policy.Commission.Rate.Min),
+			d.optionalDec("commission.rate.max", policy.Commission.Rate.Max),
+			func(c stakingtypes.CommissionRates) sdk.Dec { return c.Rate }},
+		{"commission max rate", d.optionalDec("commission.max_rate.min", policy.Commission.MaxRate.Min),
+			d.optionalDec("commission.max_rate.max", policy.Commission.MaxRate.Max),
+			func(c stakingtypes.CommissionRates) sdk.Dec { return c.MaxRate }},
+		{"commission max change rate", d.optionalDec("commission.max_change_rate.min", policy.Commission.MaxChangeRate.Min),
+			d.optionalDec("commission.max_change_rate.max", policy.Commission.MaxChangeRate.Max),
+			func(c stakingtypes.CommissionRates) sdk.Dec { return c.MaxChangeRate }},
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", d.err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gentx directory: %w", err)
+	}
+
+	checks := []GenTxCheck{}
+	monikers := map[string]string{}
+	operators := map[string]string{}
+	consensusKeys := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		check := GenTxCheck{File: file.Name(), Failures: []string{}}
+		tx, msg, err := readGenTx(clientCtx.TxConfig.TxJSONDecoder(), filepath.Join(dir, file.Name()))
+		if err != nil {
+			check.fail("%s", err)
+			checks = append(checks, check)
+			continue
+		}
+		check.Moniker = msg.Description.Moniker
+		check.Validator = msg.ValidatorAddress
+
+		if err := msg.ValidateBasic(); err != nil {
+			check.fail("invalid MsgCreateValidator: %s", err)
+		}
+		if err := verifyGenTxSignature(clientCtx, tx, msg, chainID); err != nil {
+			check.fail("%s", err)
+		}
+
+		// Self-delegation
+		if msg.Value.Denom != bondDenom {
+			check.fail("self-delegation denom %s is not the bond denom %s", msg.Value.Denom, bondDenom)
+		}
+		if minSelfDelegation != nil && msg.Value.Amount.LT(*minSelfDelegation) {
+			check.fail("self-delegation %s is less than %s", msg.Value.Amount, minSelfDelegation)
+		}
+		if maxSelfDelegation != nil && msg.Value.Amount.GT(*maxSelfDelegation) {
+			check.fail("self-delegation %s is more than %s", msg.Value.Amount, maxSelfDelegation)
+		}
+
+		// Commission
+		for _, bound := range rateBounds {
+			rate := bound.rate(msg.Commission)
+			if bound.min != nil && rate.LT(*bound.min) {
+				check.fail("%s %s is less than %s", bound.name, rate, bound.min)
+			}
+			if bound.max != nil && rate.GT(*bound.max) {
+				check.fail("%s %s is more than %s", bound.name, rate, bound.max)
+			}
+		}
+
+		// Uniqueness
+		moniker := strings.ToLower(strings.TrimSpace(msg.Description.Moniker))
+		if other, ok := monikers[moniker]; ok {
+			check.fail("moniker %q is already used by %s", msg.Description.Moniker, other)
+		} else {
+			monikers[moniker] = file.Name()
+		}
+		if other, ok := operators[msg.ValidatorAddress]; ok {
+			check.fail("validator %s is already created by %s", msg.ValidatorAddress, other)
+		} else {
+			operators[msg.ValidatorAddress] = file.Name()
+		}
+
+		// Consensus key
+		if pubKey, ok := msg.Pubkey.GetCachedValue().(cryptotypes.PubKey); !ok {
+			check.fail("invalid consensus key")
+		} else {
+			if !containsString(pubKeyTypes, pubKey.Type()) {
+				check.fail("consensus key type %s is not one of %v", pubKey.Type(), pubKeyTypes)
+			}
+			key := pubKey.String()
+			if other, ok := consensusKeys[key]; ok {
+				check.fail("consensus key is already used by %s", other)
+			} else {
+				consensusKeys[key] = file.Name()
+			}
+		}
+
+		// Genesis account
+		if !accounts[msg.DelegatorAddress] {
+			check.fail("delegator %s has no genesis account", msg.DelegatorAddress)
+		} else if balance := balances[msg.DelegatorAddress].AmountOf(msg.Value.Denom); balance.LT(msg.Value.Amount) {
+			check.fail("delegator %s has %s%s, less than the self-delegation %s",
+				msg.DelegatorAddress, balance, msg.Value.Denom, msg.Value)
+		}
+
+		check.Passed = len(check.Failures) == 0
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// verifyGenTxSignature verifies that tx is signed by the delegator of msg for
+// chainID, with the account number and sequence of a genesis account.
+func verifyGenTxSignature(clientCtx client.Context, tx sdk.Tx, msg *stakingtypes.MsgCreateValidator, chainID string) error {
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return fmt.Errorf("gentx cannot be verified")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return fmt.Errorf("invalid gentx signatures: %w", err)
+	}
+	if len(sigs) != 1 {
+		return fmt.Errorf("gentx must have exactly one signature, got %d", len(sigs))
+	}
+	if sigs[0].PubKey == nil || sdk.AccAddress(sigs[0].PubKey.Address()).String() != msg.DelegatorAddress {
+		return fmt.Errorf("gentx is not signed by the delegator %s", msg.DelegatorAddress)
+	}
+	signerData := authsigning.SignerData{ChainID: chainID, AccountNumber: 0, Sequence: 0}
+	if err := authsigning.VerifySignature(sigs[0].PubKey, signerData, sigs[0].Data, clientCtx.TxConfig.SignModeHandler(), tx); err != nil {
+		return fmt.Errorf("gentx signature is invalid for chain id %s", chainID)
+	}
+	return nil
+}
+
+func writeGenTxChecks(w io.Writer, checks []GenTxCheck) {
+	passed := 0
+	for _, check := range checks {
+		if check.Passed {
+			passed++
+			fmt.Fprintf(w, "PASS %s %s %s\n", check.File, check.Moniker, check.Validator)
+			continue
+		}
+		fmt.Fprintf(w, "FAIL %s %s %s\n", check.File, check.Moniker, check.Validator)
+		for _, failure := range check.Failures {
+			fmt.Fprintf(w, "  - %s\n", failure)
+		}
+	}
+	fmt.Fprintf(w, "%d of %d gentxs passed\n", passed, len(checks))
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
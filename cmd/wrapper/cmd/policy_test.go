@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	chain "github.com/crescent-network/crescent/app"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestCheckGenTxs(t *testing.T) {
+	clientCtx := newTestClientCtx(t)
+
+	kr := keyring.NewInMemory()
+	dir := t.TempDir()
+	writeGenTx := func(uid, moniker, rate string) sdk.AccAddress {
+		addr, bz := signGenTx(t, clientCtx, kr, "crescent-1", uid, moniker, rate, sdk.NewInt64Coin("ucre", 1000000))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, uid+".json"), bz, 0600))
+		return addr
+	}
+	funded := writeGenTx("a", "Validator", "0.1")
+	writeGenTx("b", "validator ", "0.5")
+
+	appState := chain.ModuleBasics.DefaultGenesis(clientCtx.Codec)
+	authGenState := authtypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	accs, err := authtypes.PackAccounts(authtypes.GenesisAccounts{authtypes.NewBaseAccount(funded, nil, 0, 0)})
+	require.NoError(t, err)
+	authGenState.Accounts = accs
+	appState[authtypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(&authGenState)
+	bankGenState := banktypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	bankGenState.Balances = []banktypes.Balance{{Address: funded.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("ucre", 1000000))}}
+	appState[banktypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(bankGenState)
+	stakingGenState := stakingtypes.DefaultGenesisState()
+	stakingGenState.Params.BondDenom = "ucre"
+	appState[stakingtypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(stakingGenState)
+	appStateJSON, err := json.Marshal(appState)
+	require.NoError(t, err)
+	genDoc := &tmtypes.GenesisDoc{ChainID: "crescent-1", ConsensusParams: tmtypes.DefaultConsensusParams(), AppState: appStateJSON}
+
+	policy := &cmd.GenTxPolicy{}
+	policy.Commission.Rate.Max = "0.2"
+	checks, err := cmd.CheckGenTxs(clientCtx, genDoc, dir, policy)
+	require.NoError(t, err)
+	require.Len(t, checks, 2)
+	require.True(t, checks[0].Passed, checks[0].Failures)
+	require.False(t, checks[1].Passed)
+	require.Len(t, checks[1].Failures, 3)
+	require.Contains(t, checks[1].Failures[0], "commission rate 0.500000000000000000 is more than 0.2")
+	require.Contains(t, checks[1].Failures[1], "is already used by a.json")
+	require.Contains(t, checks[1].Failures[2], "has no genesis account")
+
+	policy.ChainId = "other-1"
+	checks, err = cmd.CheckGenTxs(clientCtx, genDoc, dir, policy)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gentx signature is invalid for chain id other-1"}, checks[0].Failures)
+}
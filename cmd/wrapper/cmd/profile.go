@@ -0,0 +1,1004 @@
+package cmd
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v2/modules/apps/transfer/types"
+	budgettypes "github.com/tendermint/budget/x/budget/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+	farmingtypes "github.com/crescent-network/crescent/x/farming/types"
+	liquiditytypes "github.com/crescent-network/crescent/x/liquidity/types"
+	liquidstakingtypes "github.com/crescent-network/crescent/x/liquidstaking/types"
+	minttypes "github.com/crescent-network/crescent/x/mint/types"
+)
+
+// ProfileVersion is the network profile schema version understood by this build.
+const ProfileVersion = 1
+
+//go:embed profiles/*.yaml
+var builtinProfiles embed.FS
+
+// Profile is the declarative description of a network's genesis parameters.
+// Amounts are integer strings (underscores are allowed as digit separators),
+// rates are decimal strings, durations use Go duration syntax (e.g. 336h) and
+// times are either RFC3339 or an offset from the genesis time such as 0, 6mo or 1y.
+type Profile struct {
+	Version         int    `yaml:"version" json:"version"`
+	Name            string `yaml:"name" json:"name"`
+	GenesisTime     string `yaml:"genesis_time" json:"genesis_time"`
+	BondDenom       string `yaml:"bond_denom" json:"bond_denom"`
+	LiquidBondDenom string `yaml:"liquid_bond_denom" json:"liquid_bond_denom"`
+
+	ConsensusParams ConsensusParamsProfile `yaml:"consensus_params" json:"consensus_params"`
+	Auth            AuthProfile            `yaml:"auth" json:"auth"`
+	Bank            BankProfile            `yaml:"bank" json:"bank"`
+	Crisis          CrisisProfile          `yaml:"crisis" json:"crisis"`
+	Distribution    DistributionProfile    `yaml:"distribution" json:"distribution"`
+	Staking         StakingProfile         `yaml:"staking" json:"staking"`
+	Slashing        SlashingProfile        `yaml:"slashing" json:"slashing"`
+	Gov             GovProfile             `yaml:"gov" json:"gov"`
+	Mint            MintProfile            `yaml:"mint" json:"mint"`
+	Budget          BudgetProfile          `yaml:"budget" json:"budget"`
+	Farming         FarmingProfile         `yaml:"farming" json:"farming"`
+	Liquidity       LiquidityProfile       `yaml:"liquidity" json:"liquidity"`
+	LiquidStaking   LiquidStakingProfile   `yaml:"liquidstaking" json:"liquidstaking"`
+
+	Airdrop           *AirdropProfile   `yaml:"airdrop,omitempty" json:"airdrop,omitempty"`
+	Airdrops          []AirdropProfile  `yaml:"airdrops,omitempty" json:"airdrops,omitempty"`
+	Addresses         *AddressesProfile `yaml:"addresses,omitempty" json:"addresses,omitempty"`
+	Vesting           *VestingProfile   `yaml:"vesting,omitempty" json:"vesting,omitempty"`
+	Foundation        FoundationProfile `yaml:"foundation" json:"foundation"`
+	ValidatorBalances []BalanceProfile  `yaml:"validator_balances" json:"validator_balances"`
+	Balances          []BalanceProfile  `yaml:"balances" json:"balances"`
+	GenTxs            *GenTxProfile     `yaml:"gentxs,omitempty" json:"gentxs,omitempty"`
+}
+
+type ConsensusParamsProfile struct {
+	Block struct {
+		MaxBytes   int64 `yaml:"max_bytes" json:"max_bytes"`
+		MaxGas     int64 `yaml:"max_gas" json:"max_gas"`
+		TimeIotaMs int64 `yaml:"time_iota_ms" json:"time_iota_ms"`
+	} `yaml:"block" json:"block"`
+	Evidence struct {
+		MaxAgeNumBlocks int64  `yaml:"max_age_num_blocks" json:"max_age_num_blocks"`
+		MaxAgeDuration  string `yaml:"max_age_duration" json:"max_age_duration"`
+		MaxBytes        int64  `yaml:"max_bytes" json:"max_bytes"`
+	} `yaml:"evidence" json:"evidence"`
+	Validator struct {
+		PubKeyTypes []string `yaml:"pub_key_types" json:"pub_key_types"`
+	} `yaml:"validator" json:"validator"`
+	Version struct {
+		AppVersion uint64 `yaml:"app_version" json:"app_version"`
+	} `yaml:"version" json:"version"`
+}
+
+type AuthProfile struct {
+	MaxMemoCharacters      uint64 `yaml:"max_memo_characters" json:"max_memo_characters"`
+	TxSigLimit             uint64 `yaml:"tx_sig_limit" json:"tx_sig_limit"`
+	TxSizeCostPerByte      uint64 `yaml:"tx_size_cost_per_byte" json:"tx_size_cost_per_byte"`
+	SigVerifyCostED25519   uint64 `yaml:"sig_verify_cost_ed25519" json:"sig_verify_cost_ed25519"`
+	SigVerifyCostSecp256k1 uint64 `yaml:"sig_verify_cost_secp256k1" json:"sig_verify_cost_secp256k1"`
+}
+
+type BankProfile struct {
+	DefaultSendEnabled bool `yaml:"default_send_enabled" json:"default_send_enabled"`
+	SendEnabled        []struct {
+		Denom   string `yaml:"denom" json:"denom"`
+		Enabled bool   `yaml:"enabled" json:"enabled"`
+	} `yaml:"send_enabled" json:"send_enabled"`
+}
+
+type CrisisProfile struct {
+	ConstantFee string `yaml:"constant_fee" json:"constant_fee"`
+}
+
+type DistributionProfile struct {
+	CommunityTax        string `yaml:"community_tax" json:"community_tax"`
+	BaseProposerReward  string `yaml:"base_proposer_reward" json:"base_proposer_reward"`
+	BonusProposerReward string `yaml:"bonus_proposer_reward" json:"bonus_proposer_reward"`
+	WithdrawAddrEnabled bool   `yaml:"withdraw_addr_enabled" json:"withdraw_addr_enabled"`
+}
+
+type StakingProfile struct {
+	UnbondingTime     string `yaml:"unbonding_time" json:"unbonding_time"`
+	MaxValidators     uint32 `yaml:"max_validators" json:"max_validators"`
+	MaxEntries        uint32 `yaml:"max_entries" json:"max_entries"`
+	HistoricalEntries uint32 `yaml:"historical_entries" json:"historical_entries"`
+}
+
+type SlashingProfile struct {
+	SignedBlocksWindow      int64  `yaml:"signed_blocks_window" json:"signed_blocks_window"`
+	MinSignedPerWindow      string `yaml:"min_signed_per_window" json:"min_signed_per_window"`
+	DowntimeJailDuration    string `yaml:"downtime_jail_duration" json:"downtime_jail_duration"`
+	SlashFractionDoubleSign string `yaml:"slash_fraction_double_sign" json:"slash_fraction_double_sign"`
+	SlashFractionDowntime   string `yaml:"slash_fraction_downtime" json:"slash_fraction_downtime"`
+}
+
+type GovProfile struct {
+	MinDeposit       string `yaml:"min_deposit" json:"min_deposit"`
+	MaxDepositPeriod string `yaml:"max_deposit_period" json:"max_deposit_period"`
+	VotingPeriod     string `yaml:"voting_period" json:"voting_period"`
+	Quorum           string `yaml:"quorum" json:"quorum"`
+	Threshold        string `yaml:"threshold" json:"threshold"`
+	VetoThreshold    string `yaml:"veto_threshold" json:"veto_threshold"`
+}
+
+type MintProfile struct {
+	BlockTimeThreshold string `yaml:"block_time_threshold" json:"block_time_threshold"`
+	InflationSchedules []struct {
+		StartTime string `yaml:"start_time" json:"start_time"`
+		EndTime   string `yaml:"end_time" json:"end_time"`
+		Amount    string `yaml:"amount" json:"amount"`
+	} `yaml:"inflation_schedules" json:"inflation_schedules"`
+}
+
+type BudgetProfile struct {
+	EpochBlocks uint32 `yaml:"epoch_blocks" json:"epoch_blocks"`
+	Budgets     []struct {
+		Name               string `yaml:"name" json:"name"`
+		Rate               string `yaml:"rate" json:"rate"`
+		SourceAddress      string `yaml:"source_address" json:"source_address"`
+		DestinationAddress string `yaml:"destination_address" json:"destination_address"`
+		StartTime          string `yaml:"start_time" json:"start_time"`
+		EndTime            string `yaml:"end_time" json:"end_time"`
+	} `yaml:"budgets" json:"budgets"`
+}
+
+type FarmingProfile struct {
+	PrivatePlanCreationFee string `yaml:"private_plan_creation_fee" json:"private_plan_creation_fee"`
+	NextEpochDays          uint32 `yaml:"next_epoch_days" json:"next_epoch_days"`
+	FarmingFeeCollector    string `yaml:"farming_fee_collector" json:"farming_fee_collector"`
+	DelayedStakingGasFee   uint64 `yaml:"delayed_staking_gas_fee" json:"delayed_staking_gas_fee"`
+	MaxNumPrivatePlans     uint32 `yaml:"max_num_private_plans" json:"max_num_private_plans"`
+}
+
+type LiquidityProfile struct {
+	BatchSize                uint32 `yaml:"batch_size" json:"batch_size"`
+	TickPrecision            uint32 `yaml:"tick_precision" json:"tick_precision"`
+	FeeCollectorAddress      string `yaml:"fee_collector_address" json:"fee_collector_address"`
+	DustCollectorAddress     string `yaml:"dust_collector_address" json:"dust_collector_address"`
+	MinInitialPoolCoinSupply string `yaml:"min_initial_pool_coin_supply" json:"min_initial_pool_coin_supply"`
+	PairCreationFee          string `yaml:"pair_creation_fee" json:"pair_creation_fee"`
+	PoolCreationFee          string `yaml:"pool_creation_fee" json:"pool_creation_fee"`
+	MinInitialDepositAmount  string `yaml:"min_initial_deposit_amount" json:"min_initial_deposit_amount"`
+	MaxPriceLimitRatio       string `yaml:"max_price_limit_ratio" json:"max_price_limit_ratio"`
+	MaxOrderLifespan         string `yaml:"max_order_lifespan" json:"max_order_lifespan"`
+	SwapFeeRate              string `yaml:"swap_fee_rate" json:"swap_fee_rate"`
+	WithdrawFeeRate          string `yaml:"withdraw_fee_rate" json:"withdraw_fee_rate"`
+	DepositExtraGas          uint64 `yaml:"deposit_extra_gas" json:"deposit_extra_gas"`
+	WithdrawExtraGas         uint64 `yaml:"withdraw_extra_gas" json:"withdraw_extra_gas"`
+	OrderExtraGas            uint64 `yaml:"order_extra_gas" json:"order_extra_gas"`
+}
+
+type LiquidStakingProfile struct {
+	WhitelistedValidators []struct {
+		ValidatorAddress string `yaml:"validator_address" json:"validator_address"`
+		TargetWeight     string `yaml:"target_weight" json:"target_weight"`
+	} `yaml:"whitelisted_validators" json:"whitelisted_validators"`
+	UnstakeFeeRate         string `yaml:"unstake_fee_rate" json:"unstake_fee_rate"`
+	MinLiquidStakingAmount string `yaml:"min_liquid_staking_amount" json:"min_liquid_staking_amount"`
+}
+
+// AirdropProfile defines an airdrop. Of every row of the airdrop file, the
+// genesis ratio is set as genesis balance and the rest is claimable in equal
+// parts by completing the conditions.
+//
+// A profile has either a single airdrop, whose source account is funded with
+// the dexdrop supply less the genesis balances plus the boostdrop supply, or a
+// list of airdrops, whose source accounts are funded with exactly their
+// claimable coins. The supply of a listed airdrop only caps its airdrop file.
+type AirdropProfile struct {
+	Name            string   `yaml:"name" json:"name"`
+	File            string   `yaml:"file" json:"file"`
+	Id              uint64   `yaml:"id" json:"id"`
+	SourceAddress   string   `yaml:"source_address" json:"source_address"`
+	Conditions      []string `yaml:"conditions" json:"conditions"`
+	GenesisRatio    string   `yaml:"genesis_ratio" json:"genesis_ratio"` // part set as genesis balance, default 0.2
+	StartTime       string   `yaml:"start_time" json:"start_time"`
+	EndTime         string   `yaml:"end_time" json:"end_time"`
+	DEXdropSupply   string   `yaml:"dexdrop_supply" json:"dexdrop_supply"`
+	BoostdropSupply string   `yaml:"boostdrop_supply" json:"boostdrop_supply"`
+	Supply          string   `yaml:"supply" json:"supply"`
+
+	Policy *AirdropPolicyProfile `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// AirdropPolicyProfile adjusts the rows of the airdrop result file. Excluded
+// addresses may have any bech32 prefix, modules are module account names and
+// IBC escrows are port/channel pairs such as transfer/channel-0. Rows below the
+// minimum are dropped or, with below_minimum round-up, raised to it. The excess
+// of rows above the cap is burned or, with excess redistribute, shared pro rata
+// by the rows below the cap.
+type AirdropPolicyProfile struct {
+	Exclude           []string `yaml:"exclude" json:"exclude"`
+	ExcludeModules    []string `yaml:"exclude_modules" json:"exclude_modules"`
+	ExcludeIBCEscrows []string `yaml:"exclude_ibc_escrows" json:"exclude_ibc_escrows"`
+	Minimum           string   `yaml:"minimum" json:"minimum"`
+	BelowMinimum      string   `yaml:"below_minimum" json:"below_minimum"` // drop (default) or round-up
+	Cap               string   `yaml:"cap" json:"cap"`
+	Excess            string   `yaml:"excess" json:"excess"` // burn (default) or redistribute
+}
+
+// AddressesProfile configures the conversion of the addresses of the airdrop
+// and vesting files. The chains are added to the builtin registry of source
+// chains, replacing those with the same prefix.
+type AddressesProfile struct {
+	Unsafe      string        `yaml:"unsafe" json:"unsafe"` // reject (default) or flag
+	Allow32Byte bool          `yaml:"allow_32_byte" json:"allow_32_byte"`
+	Chains      []SourceChain `yaml:"chains" json:"chains"`
+}
+
+type VestingProfile struct {
+	File string `yaml:"file" json:"file"`
+	// Schedules are the vesting schedule templates by name. Rows that do not
+	// name a schedule use "default", which is the standard 1 year cliff and
+	// 34/34/32 schedule unless it is defined here.
+	Schedules map[string]VestingScheduleProfile `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+}
+
+// VestingScheduleProfile is a vesting schedule template. Lengths are fixed
+// lengths such as 1y, 1mo, 30d or a Go duration, where a year is 365 days and a
+// month is a twelfth of a year.
+type VestingScheduleProfile struct {
+	Cliff     string                `yaml:"cliff" json:"cliff"`
+	Remainder string                `yaml:"remainder" json:"remainder"` // first or last
+	Phases    []VestingPhaseProfile `yaml:"phases" json:"phases"`
+}
+
+type VestingPhaseProfile struct {
+	Ratio    string `yaml:"ratio" json:"ratio"`
+	Tranches int    `yaml:"tranches" json:"tranches"`
+	Interval string `yaml:"interval" json:"interval"`
+}
+
+// GenTxProfile derives the validator balances and the liquid staking whitelist
+// from a gentx directory. Funding is the bond denom amount the account of every
+// gentx validator holds at least, and defaults to its self-delegation. Validators
+// that are not in liquidstaking.whitelisted_validators are whitelisted with
+// TargetWeight, which defaults to 10.
+type GenTxProfile struct {
+	Dir          string `yaml:"dir" json:"dir"`
+	Funding      string `yaml:"funding" json:"funding"`
+	TargetWeight string `yaml:"target_weight" json:"target_weight"`
+}
+
+type FoundationProfile struct {
+	Address string `yaml:"address" json:"address"`
+	Supply  string `yaml:"supply" json:"supply"`
+}
+
+type BalanceProfile struct {
+	Address string `yaml:"address" json:"address"`
+	Coins   string `yaml:"coins" json:"coins"`
+}
+
+// LoadProfile reads a network profile from a YAML or JSON file.
+// The format is chosen by the file extension; anything but .json is read as YAML.
+func LoadProfile(path string) (*Profile, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+	isJSON := strings.EqualFold(filepath.Ext(path), ".json")
+	profile, err := ParseProfile(bz, isJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// BuiltinProfile returns the network profile shipped with the binary.
+func BuiltinProfile(name string) (*Profile, error) {
+	bz, err := builtinProfiles.ReadFile("profiles/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown builtin profile %q", name)
+	}
+	return ParseProfile(bz, false)
+}
+
+// ParseProfile decodes a network profile. Unknown fields are rejected so that a
+// misspelled key cannot silently fall back to a zero value.
+func ParseProfile(bz []byte, isJSON bool) (*Profile, error) {
+	profile := &Profile{}
+	if isJSON {
+		dec := json.NewDecoder(bytes.NewReader(bz))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(profile); err != nil {
+			return nil, fmt.Errorf("failed to decode profile: %w", err)
+		}
+	} else if err := yaml.UnmarshalStrict(bz, profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	if profile.Version != ProfileVersion {
+		return nil, fmt.Errorf("unsupported profile version %d, expected %d", profile.Version, ProfileVersion)
+	}
+	return profile, nil
+}
+
+// ResolveFiles resolves the airdrop and vesting files and the gentx directory
+// against home unless they are absolute, and checks that they exist.
+func (p *Profile) ResolveFiles(home string) error {
+	resolve := func(field, path string) (string, error) {
+		if path == "" {
+			return "", fmt.Errorf("%s is not set", field)
+		}
+		resolved := path
+		if !filepath.IsAbs(path) {
+			resolved = filepath.Join(home, path)
+		}
+		if _, err := os.Stat(resolved); err != nil {
+			if os.IsNotExist(err) {
+				return "", fmt.Errorf("%s %s does not exist (relative paths are resolved against --home %s)", field, resolved, home)
+			}
+			return "", fmt.Errorf("%s: %w", field, err)
+		}
+		return resolved, nil
+	}
+
+	var err error
+	for _, a := range p.AirdropProfiles() {
+		if a.File, err = resolve("airdrop file", a.File); err != nil {
+			return err
+		}
+	}
+	if p.Vesting != nil {
+		if p.Vesting.File, err = resolve("vesting file", p.Vesting.File); err != nil {
+			return err
+		}
+	}
+	if p.GenTxs != nil {
+		if p.GenTxs.Dir, err = resolve("gentx directory", p.GenTxs.Dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AirdropProfiles returns the single airdrop or the list of airdrops of the
+// profile.
+func (p *Profile) AirdropProfiles() []*AirdropProfile {
+	if p.Airdrop != nil {
+		return []*AirdropProfile{p.Airdrop}
+	}
+	airdrops := []*AirdropProfile{}
+	for i := range p.Airdrops {
+		airdrops = append(airdrops, &p.Airdrops[i])
+	}
+	return airdrops
+}
+
+// GenesisStates converts the profile into GenesisStates, including the
+// accounts, balances and claim records derived from the airdrop and vesting files.
+func (p *Profile) GenesisStates() (*GenesisStates, error) {
+	d := &profileDecoder{}
+
+	genParams := &GenesisStates{}
+	genParams.BondDenom = p.BondDenom
+	genParams.GenesisTime = d.time("genesis_time", p.GenesisTime)
+	if d.err != nil {
+		return nil, d.err
+	}
+	genesisTime := genParams.GenesisTime
+
+	// Set consensus params
+	cp := p.ConsensusParams
+	genParams.ConsensusParams = &tmproto.ConsensusParams{
+		Block: tmproto.BlockParams{
+			MaxBytes:   cp.Block.MaxBytes,
+			MaxGas:     cp.Block.MaxGas,
+			TimeIotaMs: cp.Block.TimeIotaMs,
+		},
+		Evidence: tmproto.EvidenceParams{
+			MaxAgeNumBlocks: cp.Evidence.MaxAgeNumBlocks,
+			MaxAgeDuration:  d.duration("consensus_params.evidence.max_age_duration", cp.Evidence.MaxAgeDuration),
+			MaxBytes:        cp.Evidence.MaxBytes,
+		},
+		Validator: tmproto.ValidatorParams{
+			PubKeyTypes: append([]string{}, cp.Validator.PubKeyTypes...),
+		},
+		Version: tmproto.VersionParams{
+			AppVersion: cp.Version.AppVersion,
+		},
+	}
+
+	// Set auth params
+	genParams.AuthParams = authtypes.Params{
+		MaxMemoCharacters:      p.Auth.MaxMemoCharacters,
+		TxSigLimit:             p.Auth.TxSigLimit,
+		TxSizeCostPerByte:      p.Auth.TxSizeCostPerByte,
+		SigVerifyCostED25519:   p.Auth.SigVerifyCostED25519,
+		SigVerifyCostSecp256k1: p.Auth.SigVerifyCostSecp256k1,
+	}
+
+	// Set bank params
+	genParams.BankParams = banktypes.Params{
+		DefaultSendEnabled: p.Bank.DefaultSendEnabled,
+	}
+	for _, se := range p.Bank.SendEnabled {
+		genParams.BankParams.SendEnabled = append(genParams.BankParams.SendEnabled, banktypes.NewSendEnabled(se.Denom, se.Enabled))
+	}
+
+	// Set crisis genesis states
+	genParams.CrisisStates = crisistypes.GenesisState{
+		ConstantFee: d.coin("crisis.constant_fee", p.Crisis.ConstantFee),
+	}
+
+	// Set distribution params
+	genParams.DistributionParams = distrtypes.Params{
+		CommunityTax:        d.dec("distribution.community_tax", p.Distribution.CommunityTax),
+		BaseProposerReward:  d.dec("distribution.base_proposer_reward", p.Distribution.BaseProposerReward),
+		BonusProposerReward: d.dec("distribution.bonus_proposer_reward", p.Distribution.BonusProposerReward),
+		WithdrawAddrEnabled: p.Distribution.WithdrawAddrEnabled,
+	}
+
+	// Set staking params
+	genParams.StakingParams = stakingtypes.Params{
+		UnbondingTime:     d.duration("staking.unbonding_time", p.Staking.UnbondingTime),
+		MaxValidators:     p.Staking.MaxValidators,
+		MaxEntries:        p.Staking.MaxEntries,
+		HistoricalEntries: p.Staking.HistoricalEntries,
+		BondDenom:         genParams.BondDenom,
+	}
+
+	// Set mint params
+	genParams.MintParams = minttypes.Params{
+		MintDenom:          genParams.BondDenom,
+		BlockTimeThreshold: d.duration("mint.block_time_threshold", p.Mint.BlockTimeThreshold),
+		InflationSchedules: []minttypes.InflationSchedule{},
+	}
+	for i, s := range p.Mint.InflationSchedules {
+		field := fmt.Sprintf("mint.inflation_schedules[%d]", i)
+		genParams.MintParams.InflationSchedules = append(genParams.MintParams.InflationSchedules, minttypes.InflationSchedule{
+			StartTime: d.offsetTime(field+".start_time", genesisTime, s.StartTime),
+			EndTime:   d.offsetTime(field+".end_time", genesisTime, s.EndTime),
+			Amount:    d.int(field+".amount", s.Amount),
+		})
+	}
+
+	// Set slashing params
+	genParams.SlashingParams = slashingtypes.Params{
+		SignedBlocksWindow:      p.Slashing.SignedBlocksWindow,
+		MinSignedPerWindow:      d.dec("slashing.min_signed_per_window", p.Slashing.MinSignedPerWindow),
+		DowntimeJailDuration:    d.duration("slashing.downtime_jail_duration", p.Slashing.DowntimeJailDuration),
+		SlashFractionDoubleSign: d.dec("slashing.slash_fraction_double_sign", p.Slashing.SlashFractionDoubleSign),
+		SlashFractionDowntime:   d.dec("slashing.slash_fraction_downtime", p.Slashing.SlashFractionDowntime),
+	}
+
+	// Set farming params
+	genParams.FarmingParams = farmingtypes.Params{
+		PrivatePlanCreationFee: d.coins("farming.private_plan_creation_fee", p.Farming.PrivatePlanCreationFee),
+		NextEpochDays:          p.Farming.NextEpochDays,
+		FarmingFeeCollector:    p.Farming.FarmingFeeCollector,
+		DelayedStakingGasFee:   sdk.Gas(p.Farming.DelayedStakingGasFee),
+		MaxNumPrivatePlans:     p.Farming.MaxNumPrivatePlans,
+	}
+
+	// Set liquidstaking params
+	genParams.LiquidStakingParams = liquidstakingtypes.Params{
+		LiquidBondDenom:        p.LiquidBondDenom,
+		WhitelistedValidators:  []liquidstakingtypes.WhitelistedValidator{},
+		UnstakeFeeRate:         d.dec("liquidstaking.unstake_fee_rate", p.LiquidStaking.UnstakeFeeRate),
+		MinLiquidStakingAmount: d.int("liquidstaking.min_liquid_staking_amount", p.LiquidStaking.MinLiquidStakingAmount),
+	}
+	for i, wv := range p.LiquidStaking.WhitelistedValidators {
+		field := fmt.Sprintf("liquidstaking.whitelisted_validators[%d]", i)
+		genParams.LiquidStakingParams.WhitelistedValidators = append(genParams.LiquidStakingParams.WhitelistedValidators, liquidstakingtypes.WhitelistedValidator{
+			ValidatorAddress: wv.ValidatorAddress,
+			TargetWeight:     d.int(field+".target_weight", wv.TargetWeight),
+		})
+	}
+
+	// Set liquidity params
+	genParams.LiquidityParams = liquiditytypes.Params{
+		BatchSize:                p.Liquidity.BatchSize,
+		TickPrecision:            p.Liquidity.TickPrecision,
+		FeeCollectorAddress:      p.Liquidity.FeeCollectorAddress,
+		DustCollectorAddress:     p.Liquidity.DustCollectorAddress,
+		MinInitialPoolCoinSupply: d.int("liquidity.min_initial_pool_coin_supply", p.Liquidity.MinInitialPoolCoinSupply),
+		PairCreationFee:          d.coins("liquidity.pair_creation_fee", p.Liquidity.PairCreationFee),
+		PoolCreationFee:          d.coins("liquidity.pool_creation_fee", p.Liquidity.PoolCreationFee),
+		MinInitialDepositAmount:  d.int("liquidity.min_initial_deposit_amount", p.Liquidity.MinInitialDepositAmount),
+		DepositExtraGas:          sdk.Gas(p.Liquidity.DepositExtraGas),
+		WithdrawExtraGas:         sdk.Gas(p.Liquidity.WithdrawExtraGas),
+		OrderExtraGas:            sdk.Gas(p.Liquidity.OrderExtraGas),
+		MaxPriceLimitRatio:       d.dec("liquidity.max_price_limit_ratio", p.Liquidity.MaxPriceLimitRatio),
+		MaxOrderLifespan:         d.duration("liquidity.max_order_lifespan", p.Liquidity.MaxOrderLifespan),
+		SwapFeeRate:              d.dec("liquidity.swap_fee_rate", p.Liquidity.SwapFeeRate),
+		WithdrawFeeRate:          d.dec("liquidity.withdraw_fee_rate", p.Liquidity.WithdrawFeeRate),
+	}
+
+	// Set gov params
+	genParams.GovParams = govtypes.Params{
+		DepositParams: govtypes.DepositParams{
+			MinDeposit:       d.coins("gov.min_deposit", p.Gov.MinDeposit),
+			MaxDepositPeriod: d.duration("gov.max_deposit_period", p.Gov.MaxDepositPeriod),
+		},
+		VotingParams: govtypes.VotingParams{
+			VotingPeriod: d.duration("gov.voting_period", p.Gov.VotingPeriod),
+		},
+		TallyParams: govtypes.TallyParams{
+			Quorum:        d.dec("gov.quorum", p.Gov.Quorum),
+			Threshold:     d.dec("gov.threshold", p.Gov.Threshold),
+			VetoThreshold: d.dec("gov.veto_threshold", p.Gov.VetoThreshold),
+		},
+	}
+
+	// Set budget params
+	genParams.BudgetParams = budgettypes.Params{
+		EpochBlocks: p.Budget.EpochBlocks,
+		Budgets:     []budgettypes.Budget{},
+	}
+	for i, b := range p.Budget.Budgets {
+		field := fmt.Sprintf("budget.budgets[%d]", i)
+		genParams.BudgetParams.Budgets = append(genParams.BudgetParams.Budgets, budgettypes.Budget{
+			Name:               b.Name,
+			Rate:               d.dec(field+".rate", b.Rate),
+			SourceAddress:      b.SourceAddress,
+			DestinationAddress: b.DestinationAddress,
+			StartTime:          d.offsetTime(field+".start_time", genesisTime, b.StartTime),
+			EndTime:            d.offsetTime(field+".end_time", genesisTime, b.EndTime),
+		})
+	}
+
+	in := genesisAccountsInput{
+		FoundationAddress: p.Foundation.Address,
+		FoundationSupply:  d.amount("foundation.supply", p.Foundation.Supply),
+		ValidatorBalances: d.balances("validator_balances", p.ValidatorBalances),
+		Balances:          d.balances("balances", p.Balances),
+	}
+
+	in.Addresses = d.addressPolicy("addresses", p.Addresses)
+
+	// Set airdrop definitions
+	if p.Airdrop != nil && len(p.Airdrops) > 0 {
+		d.fail("airdrops", "airdrop list", "", fmt.Errorf("cannot be used together with airdrop"))
+	}
+	if a := p.Airdrop; a != nil {
+		in.Airdrops = append(in.Airdrops, d.airdrop("airdrop", a, genesisTime, true))
+	}
+	for i := range p.Airdrops {
+		in.Airdrops = append(in.Airdrops, d.airdrop(fmt.Sprintf("airdrops[%d]", i), &p.Airdrops[i], genesisTime, false))
+	}
+	ids := map[uint64]bool{}
+	sources := map[string]bool{}
+	for i, a := range in.Airdrops {
+		field := fmt.Sprintf("airdrops[%d]", i)
+		if p.Airdrop != nil {
+			field = "airdrop"
+		}
+		// The claim module panics on duplicate IDs, and sweeps the whole
+		// balance of the source account when an airdrop ends
+		if ids[a.Airdrop.Id] {
+			d.fail(field+".id", "airdrop id", fmt.Sprint(a.Airdrop.Id), fmt.Errorf("duplicate airdrop id"))
+		}
+		if sources[a.Airdrop.SourceAddress] {
+			d.fail(field+".source_address", "address", a.Airdrop.SourceAddress, fmt.Errorf("every airdrop needs its own source account"))
+		}
+		ids[a.Airdrop.Id] = true
+		sources[a.Airdrop.SourceAddress] = true
+		genParams.ClaimGenesisState.Airdrops = append(genParams.ClaimGenesisState.Airdrops, a.Airdrop)
+	}
+
+	if v := p.Vesting; v != nil {
+		in.VestingFile = v.File
+		if in.VestingFile == "" {
+			d.fail("vesting.file", "file", v.File, nil)
+		}
+		in.VestingSchedules = d.vestingSchedules(v)
+	}
+
+	if g := p.GenTxs; g != nil && d.err == nil {
+		validators, err := ParseGenTxValidators(g.Dir, genParams.BondDenom)
+		if err != nil {
+			return nil, err
+		}
+		in.GenTxValidators = validators
+		if g.Funding != "" {
+			funding := d.amount("gentxs.funding", g.Funding)
+			in.GenTxFunding = &funding
+		}
+		targetWeight := sdk.NewInt(DefaultWhitelistTargetWeight)
+		if g.TargetWeight != "" {
+			targetWeight = d.int("gentxs.target_weight", g.TargetWeight)
+		}
+
+		// Whitelist the gentx validators that are not whitelisted yet
+		whitelisted := map[string]bool{}
+		for _, wv := range genParams.LiquidStakingParams.WhitelistedValidators {
+			whitelisted[wv.ValidatorAddress] = true
+		}
+		for _, validator := range validators {
+			if whitelisted[validator.ValidatorAddress] {
+				continue
+			}
+			genParams.LiquidStakingParams.WhitelistedValidators = append(genParams.LiquidStakingParams.WhitelistedValidators, liquidstakingtypes.WhitelistedValidator{
+				ValidatorAddress: validator.ValidatorAddress,
+				TargetWeight:     targetWeight,
+			})
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if err := setGenesisAccounts(genParams, in); err != nil {
+		return nil, err
+	}
+
+	return genParams, nil
+}
+
+// VestingSchedules returns the vesting schedule templates of the profile.
+func (p *Profile) VestingSchedules() (VestingSchedules, error) {
+	d := &profileDecoder{}
+	v := p.Vesting
+	if v == nil {
+		v = &VestingProfile{}
+	}
+	schedules := d.vestingSchedules(v)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return schedules, nil
+}
+
+// AddressPolicy decodes the address conversion policy of the profile.
+func (p *Profile) AddressPolicy() (*AddressPolicy, error) {
+	d := &profileDecoder{}
+	policy := d.addressPolicy("addresses", p.Addresses)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return policy, nil
+}
+
+// profileDecoder converts profile strings into typed values. The first error
+// is kept and every later conversion becomes a no-op returning a zero value.
+type profileDecoder struct {
+	err error
+}
+
+func (d *profileDecoder) fail(field, kind, s string, err error) {
+	if d.err == nil {
+		if err != nil {
+			d.err = fmt.Errorf("%s: invalid %s %q: %w", field, kind, s, err)
+		} else {
+			d.err = fmt.Errorf("%s: invalid %s %q", field, kind, s)
+		}
+	}
+}
+
+func (d *profileDecoder) int(field, s string) sdk.Int {
+	i, ok := sdk.NewIntFromString(strings.ReplaceAll(s, "_", ""))
+	if !ok {
+		d.fail(field, "integer", s, nil)
+		return sdk.ZeroInt()
+	}
+	return i
+}
+
+// amount decodes an integer that must not be negative, such as a supply.
+func (d *profileDecoder) amount(field, s string) sdk.Int {
+	i := d.int(field, s)
+	if i.IsNegative() {
+		d.fail(field, "amount", s, fmt.Errorf("must not be negative"))
+		return sdk.ZeroInt()
+	}
+	return i
+}
+
+func (d *profileDecoder) dec(field, s string) sdk.Dec {
+	v, err := sdk.NewDecFromStr(s)
+	if err != nil {
+		d.fail(field, "decimal", s, err)
+		return sdk.ZeroDec()
+	}
+	return v
+}
+
+// optionalInt decodes an optional integer, nil if s is empty.
+func (d *profileDecoder) optionalInt(field, s string) *sdk.Int {
+	if s == "" {
+		return nil
+	}
+	i := d.int(field, s)
+	return &i
+}
+
+// optionalAmount decodes an optional amount, nil if s is empty.
+func (d *profileDecoder) optionalAmount(field, s string) *sdk.Int {
+	if s == "" {
+		return nil
+	}
+	i := d.amount(field, s)
+	return &i
+}
+
+// optionalDec decodes an optional decimal, nil if s is empty.
+func (d *profileDecoder) optionalDec(field, s string) *sdk.Dec {
+	if s == "" {
+		return nil
+	}
+	v := d.dec(field, s)
+	return &v
+}
+
+func (d *profileDecoder) coin(field, s string) sdk.Coin {
+	c, err := sdk.ParseCoinNormalized(s)
+	if err != nil {
+		d.fail(field, "coin", s, err)
+		return sdk.Coin{}
+	}
+	return c
+}
+
+func (d *profileDecoder) coins(field, s string) sdk.Coins {
+	c, err := sdk.ParseCoinsNormalized(s)
+	if err != nil {
+		d.fail(field, "coins", s, err)
+		return sdk.Coins{}
+	}
+	return c
+}
+
+func (d *profileDecoder) balances(field string, bs []BalanceProfile) []banktypes.Balance {
+	balances := []banktypes.Balance{}
+	for i, b := range bs {
+		balances = append(balances, banktypes.Balance{
+			Address: b.Address,
+			Coins:   d.coins(fmt.Sprintf("%s[%d].coins", field, i), b.Coins),
+		})
+	}
+	return balances
+}
+
+func (d *profileDecoder) duration(field, s string) time.Duration {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		d.fail(field, "duration", s, err)
+	}
+	return v
+}
+
+func (d *profileDecoder) time(field, s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		d.fail(field, "time", s, err)
+	}
+	return t
+}
+
+var offsetRegexp = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)mo)?(?:(\d+)d)?$`)
+
+// offsetTime resolves either an RFC3339 time or a calendar offset such as
+// 1y, 6mo, 1y6mo or 30d from base.
+func (d *profileDecoder) offsetTime(field string, base time.Time, s string) time.Time {
+	t, err := ParseOffsetTime(base, s)
+	if err != nil {
+		d.fail(field, "time", s, err)
+	}
+	return t
+}
+
+// airdrop decodes an airdrop definition. The single airdrop form keeps the
+// unallocated dexdrop supply and the boostdrop supply on its source account.
+func (d *profileDecoder) airdrop(field string, a *AirdropProfile, genesisTime time.Time, single bool) airdropInput {
+	in := airdropInput{
+		Name: a.Name,
+		Airdrop: claimtypes.Airdrop{
+			Id:            a.Id,
+			SourceAddress: a.SourceAddress,
+			StartTime:     d.offsetTime(field+".start_time", genesisTime, a.StartTime),
+			EndTime:       d.offsetTime(field+".end_time", genesisTime, a.EndTime),
+		},
+		File:    a.File,
+		Reserve: sdk.ZeroInt(),
+	}
+	if single {
+		if a.Supply != "" {
+			d.fail(field+".supply", "integer", a.Supply, fmt.Errorf("use dexdrop_supply and boostdrop_supply"))
+		}
+		supply := d.amount(field+".dexdrop_supply", a.DEXdropSupply)
+		in.Supply = &supply
+		in.Reserve = d.amount(field+".boostdrop_supply", a.BoostdropSupply)
+		in.KeepUnallocated = true
+	} else {
+		if a.DEXdropSupply != "" || a.BoostdropSupply != "" {
+			d.fail(field+".dexdrop_supply", "integer", a.DEXdropSupply+a.BoostdropSupply, fmt.Errorf("only valid for a single airdrop, use supply"))
+		}
+		in.Supply = d.optionalAmount(field+".supply", a.Supply)
+	}
+	seen := map[claimtypes.ConditionType]bool{}
+	for i, c := range a.Conditions {
+		conditionField := fmt.Sprintf("%s.conditions[%d]", field, i)
+		condition := d.condition(conditionField, c)
+		if seen[condition] {
+			d.fail(conditionField, "condition", c, fmt.Errorf("duplicate condition"))
+		}
+		seen[condition] = true
+		in.Airdrop.Conditions = append(in.Airdrop.Conditions, condition)
+	}
+	in.GenesisRatio = sdk.NewDecWithPrec(2, 1)
+	if a.GenesisRatio != "" {
+		in.GenesisRatio = d.dec(field+".genesis_ratio", a.GenesisRatio)
+	}
+	if in.GenesisRatio.IsNegative() || in.GenesisRatio.GT(sdk.OneDec()) {
+		d.fail(field+".genesis_ratio", "decimal", a.GenesisRatio, fmt.Errorf("must be between 0 and 1"))
+	}
+	if len(in.Airdrop.Conditions) == 0 && in.GenesisRatio.LT(sdk.OneDec()) {
+		d.fail(field+".conditions", "condition", "", fmt.Errorf("at least one condition is required unless genesis_ratio is 1"))
+	}
+	in.Policy = d.airdropPolicy(field+".policy", a.Policy)
+	if in.File == "" {
+		d.fail(field+".file", "file", a.File, nil)
+	}
+	return in
+}
+
+// addressPolicy decodes the address conversion policy, the default one if p is nil.
+func (d *profileDecoder) addressPolicy(field string, p *AddressesProfile) *AddressPolicy {
+	policy := DefaultAddressPolicy()
+	if p == nil {
+		return policy
+	}
+
+	switch p.Unsafe {
+	case "", UnsafeAddressReject:
+	case UnsafeAddressFlag:
+		policy.Flag = true
+	default:
+		d.fail(field+".unsafe", "rule", p.Unsafe, fmt.Errorf("expected %s or %s", UnsafeAddressReject, UnsafeAddressFlag))
+	}
+	policy.Allow32Byte = p.Allow32Byte
+	for i, c := range p.Chains {
+		chainField := fmt.Sprintf("%s.chains[%d]", field, i)
+		if c.Prefix == "" {
+			d.fail(chainField+".prefix", "prefix", c.Prefix, nil)
+		}
+		switch c.KeyType = keyTypeOrDefault(c.KeyType); c.KeyType {
+		case KeyTypeSecp256k1, KeyTypeEthSecp256k1:
+		default:
+			d.fail(chainField+".key_type", "key type", c.KeyType, fmt.Errorf("expected %s or %s", KeyTypeSecp256k1, KeyTypeEthSecp256k1))
+		}
+		policy.Chains[c.Prefix] = c
+	}
+	return policy
+}
+
+// airdropPolicy decodes the airdrop policy, which applies no rule if p is nil.
+func (d *profileDecoder) airdropPolicy(field string, p *AirdropPolicyProfile) *AirdropPolicy {
+	policy := &AirdropPolicy{Exclude: map[string]string{}}
+	if p == nil {
+		return policy
+	}
+
+	for i, addr := range p.Exclude {
+		converted, err := convertAddressPrefix(addr)
+		if err != nil {
+			d.fail(fmt.Sprintf("%s.exclude[%d]", field, i), "address", addr, err)
+			continue
+		}
+		policy.Exclude[converted] = "excluded address"
+	}
+	for _, name := range p.ExcludeModules {
+		policy.Exclude[authtypes.NewModuleAddress(name).String()] = "module " + name
+	}
+	for i, escrow := range p.ExcludeIBCEscrows {
+		parts := strings.Split(escrow, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			d.fail(fmt.Sprintf("%s.exclude_ibc_escrows[%d]", field, i), "port/channel", escrow, nil)
+			continue
+		}
+		policy.Exclude[ibctransfertypes.GetEscrowAddress(parts[0], parts[1]).String()] = "ibc escrow " + escrow
+	}
+
+	policy.Minimum = d.optionalAmount(field+".minimum", p.Minimum)
+	policy.Cap = d.optionalAmount(field+".cap", p.Cap)
+	switch p.BelowMinimum {
+	case "", AirdropBelowMinimumDrop:
+	case AirdropBelowMinimumRoundUp:
+		policy.RoundUp = true
+	default:
+		d.fail(field+".below_minimum", "rule", p.BelowMinimum, fmt.Errorf("expected %s or %s", AirdropBelowMinimumDrop, AirdropBelowMinimumRoundUp))
+	}
+	switch p.Excess {
+	case "", AirdropExcessBurn:
+	case AirdropExcessRedistribute:
+		policy.Redistribute = true
+	default:
+		d.fail(field+".excess", "rule", p.Excess, fmt.Errorf("expected %s or %s", AirdropExcessBurn, AirdropExcessRedistribute))
+	}
+	if policy.Minimum != nil && policy.Cap != nil && policy.Cap.LT(*policy.Minimum) {
+		d.fail(field+".cap", "cap", p.Cap, fmt.Errorf("less than the minimum %s", policy.Minimum))
+	}
+	return policy
+}
+
+// vestingSchedules returns the standard schedule as default together with the
+// schedules defined in v, which may replace it.
+func (d *profileDecoder) vestingSchedules(v *VestingProfile) VestingSchedules {
+	schedules := VestingSchedules{DefaultVestingScheduleName: StandardVestingSchedule()}
+	names := []string{}
+	for name := range v.Schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := v.Schedules[name]
+		field := fmt.Sprintf("vesting.schedules.%s", name)
+		schedule := &VestingSchedule{
+			Name:      name,
+			Cliff:     d.vestingLength(field+".cliff", s.Cliff),
+			Remainder: s.Remainder,
+		}
+		if schedule.Remainder == "" {
+			schedule.Remainder = RemainderFirst
+		}
+		for i, phase := range s.Phases {
+			phaseField := fmt.Sprintf("%s.phases[%d]", field, i)
+			schedule.Phases = append(schedule.Phases, VestingPhase{
+				Ratio:    d.dec(phaseField+".ratio", phase.Ratio),
+				Tranches: phase.Tranches,
+				Interval: d.vestingLength(phaseField+".interval", phase.Interval),
+			})
+		}
+		if d.err == nil {
+			if err := schedule.Validate(); err != nil {
+				d.fail(field, "vesting schedule", name, err)
+			}
+		}
+		schedules[name] = schedule
+	}
+	return schedules
+}
+
+// vestingLength resolves a fixed length such as 1y, 6mo, 1y6mo or 30d, or a Go
+// duration, into seconds. An empty length is 0.
+func (d *profileDecoder) vestingLength(field, s string) int64 {
+	if s == "" || s == "0" {
+		return 0
+	}
+	if m := offsetRegexp.FindStringSubmatch(s); m != nil {
+		n := make([]int64, 3)
+		for i, v := range m[1:] {
+			if v != "" {
+				n[i], _ = strconv.ParseInt(v, 10, 64)
+			}
+		}
+		return n[0]*VestingYear + n[1]*VestingMonth + n[2]*60*60*24
+	}
+	return int64(d.duration(field, s).Seconds())
+}
+
+func (d *profileDecoder) condition(field, s string) claimtypes.ConditionType {
+	name := "CONDITION_TYPE_" + strings.ToUpper(s)
+	v, ok := claimtypes.ConditionType_value[name]
+	if !ok || v == int32(claimtypes.ConditionTypeUnspecified) {
+		d.fail(field, "condition", s, nil)
+	}
+	return claimtypes.ConditionType(v)
+}
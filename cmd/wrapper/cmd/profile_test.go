@@ -0,0 +1,362 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	budgettypes "github.com/tendermint/budget/x/budget/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+	farmingtypes "github.com/crescent-network/crescent/x/farming/types"
+	liquiditytypes "github.com/crescent-network/crescent/x/liquidity/types"
+	liquidstakingtypes "github.com/crescent-network/crescent/x/liquidstaking/types"
+	minttypes "github.com/crescent-network/crescent/x/mint/types"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestBuiltinProfile(t *testing.T) {
+	profile, err := cmd.BuiltinProfile("mainnet")
+	require.NoError(t, err)
+	require.Equal(t, cmd.ProfileVersion, profile.Version)
+	require.Equal(t, "ucre", profile.BondDenom)
+	require.Len(t, profile.Mint.InflationSchedules, 10)
+
+	_, err = cmd.BuiltinProfile("unknown")
+	require.Error(t, err)
+}
+
+func TestParseProfile(t *testing.T) {
+	_, err := cmd.ParseProfile([]byte("version: 1\nbond_denom: ucre\n"), false)
+	require.NoError(t, err)
+
+	_, err = cmd.ParseProfile([]byte(`{"version": 1, "bond_denom": "ucre"}`), true)
+	require.NoError(t, err)
+
+	// unknown fields are rejected
+	_, err = cmd.ParseProfile([]byte("version: 1\nbond_denon: ucre\n"), false)
+	require.Error(t, err)
+
+	// unsupported version
+	_, err = cmd.ParseProfile([]byte("version: 2\n"), false)
+	require.Error(t, err)
+}
+
+func TestTestnetGenesisStates(t *testing.T) {
+	useCrescentConfig(t)
+
+	genStates, err := cmd.TestnetGenesisStates()
+	require.NoError(t, err)
+	require.Equal(t, "utcre", genStates.BondDenom)
+	require.Equal(t, "utcre", genStates.StakingParams.BondDenom)
+	require.Empty(t, genStates.ClaimGenesisState.Airdrops)
+	require.NotNil(t, genStates.ConsensusParams)
+
+	total := sdk.Coins{}
+	for _, balance := range genStates.BankGenesisStates.Balances {
+		total = total.Add(balance.Coins...)
+	}
+	require.Equal(t, genStates.BankGenesisStates.Supply, total)
+	require.Len(t, genStates.AuthGenesisState.Accounts, len(genStates.BankGenesisStates.Balances))
+
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+	profile.Foundation.Supply = "-1"
+	_, err = profile.GenesisStates()
+	require.EqualError(t, err, `foundation.supply: invalid amount "-1": must not be negative`)
+}
+
+func TestAirdropGenesisRatio(t *testing.T) {
+	useCrescentConfig(t)
+
+	addr := func(b byte) string { return sdk.AccAddress(bytes.Repeat([]byte{b}, 20)).String() }
+	file := filepath.Join(t.TempDir(), "result.csv")
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf("address,amount\n%s,10\n%s,7\n", addr(1), addr(2))), 0644))
+
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+	profile.Airdrop = &cmd.AirdropProfile{
+		File:            file,
+		Id:              3,
+		SourceAddress:   addr(9),
+		Conditions:      []string{"swap", "vote"},
+		GenesisRatio:    "0.25",
+		StartTime:       "0",
+		EndTime:         "1mo",
+		DEXdropSupply:   "20",
+		BoostdropSupply: "0",
+	}
+	genStates, err := profile.GenesisStates()
+	require.NoError(t, err)
+
+	// 2.5 and 1.75 are rounded down, the dust is claimable
+	require.Len(t, genStates.ClaimGenesisState.ClaimRecords, 2)
+	for i, want := range []int64{8, 6} {
+		record := genStates.ClaimGenesisState.ClaimRecords[i]
+		require.Equal(t, uint64(3), record.AirdropId)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("utcre", want)), record.InitialClaimableCoins)
+	}
+	require.Equal(t, sdk.NewDecCoinFromDec("utcre", sdk.NewDecWithPrec(125, 2)), genStates.Airdrops[0].Dust)
+	balances := map[string]sdk.Coins{}
+	for _, balance := range genStates.BankGenesisStates.Balances {
+		balances[balance.Address] = balance.Coins
+	}
+	require.Equal(t, "2utcre", balances[addr(1)].String())
+	require.Equal(t, "1utcre", balances[addr(2)].String())
+	require.Equal(t, "17utcre", balances[addr(9)].String())
+
+	// Duplicate conditions could never all be claimed
+	profile.Airdrop.Conditions = []string{"swap", "swap"}
+	_, err = profile.GenesisStates()
+	require.Error(t, err)
+
+	// Supplies cannot be negative
+	profile.Airdrop.Conditions = []string{"swap", "vote"}
+	profile.Airdrop.BoostdropSupply = "-5"
+	_, err = profile.GenesisStates()
+	require.EqualError(t, err, `airdrop.boostdrop_supply: invalid amount "-5": must not be negative`)
+}
+
+func TestMultipleAirdrops(t *testing.T) {
+	useCrescentConfig(t)
+
+	addr := func(b byte) string { return sdk.AccAddress(bytes.Repeat([]byte{b}, 20)).String() }
+	dir := t.TempDir()
+	dexdropFile := filepath.Join(dir, "dexdrop.csv")
+	boostdropFile := filepath.Join(dir, "boostdrop.csv")
+	require.NoError(t, os.WriteFile(dexdropFile, []byte(fmt.Sprintf("address,amount\n%s,100\n%s,50\n", addr(1), addr(2))), 0644))
+	require.NoError(t, os.WriteFile(boostdropFile, []byte(fmt.Sprintf("address,amount\n%s,40\n", addr(1))), 0644))
+
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+	profile.Airdrops = []cmd.AirdropProfile{
+		{Name: "dexdrop", File: dexdropFile, Id: 1, SourceAddress: addr(8), Conditions: []string{"swap"},
+			StartTime: "0", EndTime: "6mo", Supply: "1000"},
+		{Name: "boostdrop", File: boostdropFile, Id: 2, SourceAddress: addr(9), Conditions: []string{"deposit", "vote"},
+			GenesisRatio: "0", StartTime: "1mo", EndTime: "1y"},
+	}
+	before, err := cmd.TestnetGenesisStates()
+	require.NoError(t, err)
+	genStates, err := profile.GenesisStates()
+	require.NoError(t, err)
+
+	require.Len(t, genStates.ClaimGenesisState.Airdrops, 2)
+	require.Len(t, genStates.ClaimGenesisState.ClaimRecords, 3)
+	balances := map[string]sdk.Coins{}
+	for _, balance := range genStates.BankGenesisStates.Balances {
+		require.NotContains(t, balances, balance.Address)
+		balances[balance.Address] = balance.Coins
+	}
+
+	// The sources hold exactly the claimable coins and the unallocated supply is not minted
+	require.Equal(t, "120utcre", balances[addr(8)].String())
+	require.Equal(t, "40utcre", balances[addr(9)].String())
+	require.Equal(t, "20utcre", balances[addr(1)].String())
+	require.Equal(t, "10utcre", balances[addr(2)].String())
+	require.Equal(t, before.BankGenesisStates.Supply.Add(sdk.NewInt64Coin("utcre", 190)), genStates.BankGenesisStates.Supply)
+
+	// Airdrops need their own ids and source accounts
+	profile.Airdrops[1].Id = 1
+	_, err = profile.GenesisStates()
+	require.Error(t, err)
+	profile.Airdrops[1].Id = 2
+	profile.Airdrops[1].SourceAddress = addr(8)
+	_, err = profile.GenesisStates()
+	require.Error(t, err)
+
+	// Supplies cannot be negative
+	profile.Airdrops[1].SourceAddress = addr(9)
+	profile.Airdrops[0].Supply = "-1000"
+	_, err = profile.GenesisStates()
+	require.EqualError(t, err, `airdrops[0].supply: invalid amount "-1000": must not be negative`)
+}
+
+// TestMainnetProfileBaseline pins the builtin mainnet profile to the values
+// that were hard-coded before the profiles.
+func TestMainnetProfileBaseline(t *testing.T) {
+	useCrescentConfig(t)
+
+	airdropFile := filepath.Join(t.TempDir(), "result.csv")
+	content := "address,amount\ncosmos1negaxxj44xm0dfy0rxyfqtr8zeha703f56wmjx,1000000\n"
+	require.NoError(t, os.WriteFile(airdropFile, []byte(content), 0600))
+
+	profile, err := cmd.BuiltinProfile("mainnet")
+	require.NoError(t, err)
+	profile.Airdrop.File = airdropFile
+	profile.Vesting.File = vestingFilePathTest
+	genStates, err := profile.GenesisStates()
+	require.NoError(t, err)
+
+	const (
+		inflationFeeCollector   = "cre17xpfvakm2amg962yls6f84z3kell8c5l53s97s"
+		ecosystemIncentive      = "cre1kgshua58cjr2p7hnrvgun68yrqf7ktdzyz2yxv54fqj6uwl4gc4q95txqa"
+		ecosystemIncentiveLP    = "cre1wht0xhmuqph4rhzulhejgatthnpeatzjgnnkvqvphq97xr26np0qdvun2s"
+		ecosystemIncentiveMM    = "cre1ddn66jv0sjpmck0ptegmhmqtn35qsg2vxyk2hn9sqf4qxtzqz3sq3qhhde"
+		ecosystemIncentiveBoost = "cre17zftu6rg7mkmemqxv4whjkvecl0e2ja7j6um9t8qaczp79y72d7q2su2xm"
+		devTeamAddress          = "cre1ge2jm9nkvu2l8cvhc2un4m33d4yy4p0wfag09j"
+	)
+	genesisTime := time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC)
+	years := func(n int) time.Time { return genesisTime.AddDate(n, 0, 0) }
+	dec := sdk.MustNewDecFromStr
+	ucre := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin(bondDenom, amount)) }
+
+	require.Equal(t, bondDenom, genStates.BondDenom)
+	require.Equal(t, genesisTime, genStates.GenesisTime)
+
+	require.Equal(t, (&tmproto.ConsensusParams{
+		Block:     tmproto.BlockParams{MaxBytes: 10000000, MaxGas: 100000000, TimeIotaMs: 1000},
+		Evidence:  tmproto.EvidenceParams{MaxAgeNumBlocks: 201600, MaxAgeDuration: 1209600000000000, MaxBytes: 1000000},
+		Validator: tmproto.ValidatorParams{PubKeyTypes: []string{"ed25519"}},
+	}).String(), genStates.ConsensusParams.String())
+
+	authParams := authtypes.DefaultParams()
+	authParams.MaxMemoCharacters = 512
+	require.Equal(t, authParams.String(), genStates.AuthParams.String())
+	require.Equal(t, banktypes.DefaultParams().String(), genStates.BankParams.String())
+	require.Equal(t, sdk.NewInt64Coin(bondDenom, 1000), genStates.CrisisStates.ConstantFee)
+
+	require.Equal(t, distrtypes.Params{
+		CommunityTax:        dec("0.285714285700000000"),
+		BaseProposerReward:  dec("0.007142857143000000"),
+		BonusProposerReward: dec("0.028571428570000000"),
+		WithdrawAddrEnabled: true,
+	}.String(), genStates.DistributionParams.String())
+
+	require.Equal(t, stakingtypes.Params{
+		UnbondingTime:     1209600 * time.Second,
+		MaxValidators:     50,
+		MaxEntries:        28,
+		HistoricalEntries: 10000,
+		BondDenom:         bondDenom,
+	}.String(), genStates.StakingParams.String())
+
+	mintParams := minttypes.Params{MintDenom: bondDenom, BlockTimeThreshold: 10 * time.Second}
+	for i, amount := range []int64{
+		108_700000_000000, 216_100000_000000, 151_300000_000000, 105_900000_000000, 74_100000_000000,
+		51_900000_000000, 36_300000_000000, 25_400000_000000, 17_800000_000000, 12_500000_000000,
+	} {
+		mintParams.InflationSchedules = append(mintParams.InflationSchedules, minttypes.InflationSchedule{
+			StartTime: years(i), EndTime: years(i + 1), Amount: sdk.NewInt(amount),
+		})
+	}
+	require.Equal(t, mintParams.String(), genStates.MintParams.String())
+
+	require.Equal(t, (&slashingtypes.Params{
+		SignedBlocksWindow:      30000,
+		MinSignedPerWindow:      dec("0.05"),
+		DowntimeJailDuration:    60 * time.Second,
+		SlashFractionDoubleSign: dec("0.05"),
+		SlashFractionDowntime:   dec("0"),
+	}).String(), genStates.SlashingParams.String())
+
+	require.Equal(t, farmingtypes.Params{
+		PrivatePlanCreationFee: ucre(100000000),
+		NextEpochDays:          1,
+		FarmingFeeCollector:    "cre1h292smhhttwy0rl3qr4p6xsvpvxc4v05s6rxtczwq3cs6qc462mq4p6cjy",
+		DelayedStakingGasFee:   100000,
+		MaxNumPrivatePlans:     10000,
+	}.String(), genStates.FarmingParams.String())
+
+	liquidStakingParams := liquidstakingtypes.Params{
+		LiquidBondDenom:        "ubcre",
+		UnstakeFeeRate:         dec("0"),
+		MinLiquidStakingAmount: sdk.NewInt(1000000),
+	}
+	for _, addr := range []string{
+		"crevaloper1n3mhyp9fvcmuu8l0q8qvjy07x0rql8q4ep74jz",
+		"crevaloper17muws0zgrd0vzh37guea7960ym7aqf2j9v6l7s",
+		"crevaloper1ls9w867xu0q5zjze5vrakfa2zluahtv44gwn7y",
+		"crevaloper10rdgqczxyp69x9llq62cc3xs4w8w0k7p42x9jq",
+		"crevaloper1dad8evf6vw72seljuzhjgurq48egaqfndvq38v",
+		"crevaloper1zuucyy5v49lwnrdupqqafqdu29qy6wgnadwkuu",
+		"crevaloper14lultfckehtszvzw4ehu0apvsr77afvy35naks",
+		"crevaloper1qvdyzetkqq6rt4xu234xpvee5wt45a75rt2afe",
+		"crevaloper18zvtvhzrqq5ny2jpmlc6new9k4c4uzzh6tcfpt",
+		"crevaloper1pxexdsms050v35zu0vc07dk4ml647lsrjff52g",
+		"crevaloper130mdu9a0etmeuw52qfxk73pn0ga6gawk9cyq6a",
+	} {
+		liquidStakingParams.WhitelistedValidators = append(liquidStakingParams.WhitelistedValidators,
+			liquidstakingtypes.WhitelistedValidator{ValidatorAddress: addr, TargetWeight: sdk.NewInt(10)})
+	}
+	require.Equal(t, liquidStakingParams.String(), genStates.LiquidStakingParams.String())
+
+	require.Equal(t, (&liquiditytypes.Params{
+		BatchSize:                1,
+		TickPrecision:            3,
+		FeeCollectorAddress:      "cre1zdew6yxyw92z373yqp756e0x4rvd2het37j0a2wjp7fj48eevxvq303p8d",
+		DustCollectorAddress:     "cre1suads2mkd027cmfphmk9fpuwcct4d8ys02frk8e64hluswfwfj0s4xymnj",
+		MinInitialPoolCoinSupply: sdk.NewInt(1_000000_000000),
+		PairCreationFee:          ucre(100_000_000),
+		PoolCreationFee:          ucre(100_000_000),
+		MinInitialDepositAmount:  sdk.NewInt(1000000),
+		DepositExtraGas:          60000,
+		WithdrawExtraGas:         64000,
+		OrderExtraGas:            37000,
+		MaxPriceLimitRatio:       dec("0.1"),
+		MaxOrderLifespan:         86400 * time.Second,
+		SwapFeeRate:              dec("0"),
+		WithdrawFeeRate:          dec("0"),
+	}).String(), genStates.LiquidityParams.String())
+
+	require.Equal(t, govtypes.Params{
+		DepositParams: govtypes.DepositParams{MinDeposit: ucre(500000000), MaxDepositPeriod: 432000 * time.Second},
+		VotingParams:  govtypes.VotingParams{VotingPeriod: 432000 * time.Second},
+		TallyParams:   govtypes.TallyParams{Quorum: dec("0.4"), Threshold: dec("0.5"), VetoThreshold: dec("0.334")},
+	}.String(), genStates.GovParams.String())
+
+	budget := func(name, rate, source, destination string, start, end int) budgettypes.Budget {
+		return budgettypes.Budget{
+			Name: name, Rate: dec(rate), SourceAddress: source, DestinationAddress: destination,
+			StartTime: years(start), EndTime: years(end),
+		}
+	}
+	require.Equal(t, budgettypes.Params{
+		EpochBlocks: 1,
+		Budgets: []budgettypes.Budget{
+			budget("budget-ecosystem-incentive", "0.6625", inflationFeeCollector, ecosystemIncentive, 0, 10),
+			budget("budget-dev-team", "0.25", inflationFeeCollector, devTeamAddress, 0, 10),
+			budget("budget-ecosystem-incentive-lp-1", "0.5", ecosystemIncentive, ecosystemIncentiveLP, 0, 1),
+			budget("budget-ecosystem-incentive-mm-1", "0.3", ecosystemIncentive, ecosystemIncentiveMM, 0, 1),
+			budget("budget-ecosystem-incentive-boost-1", "0.2", ecosystemIncentive, ecosystemIncentiveBoost, 0, 1),
+			budget("budget-ecosystem-incentive-lp-2", "0.2", ecosystemIncentive, ecosystemIncentiveLP, 1, 2),
+			budget("budget-ecosystem-incentive-mm-2", "0.3", ecosystemIncentive, ecosystemIncentiveMM, 1, 2),
+			budget("budget-ecosystem-incentive-boost-2", "0.5", ecosystemIncentive, ecosystemIncentiveBoost, 1, 2),
+			budget("budget-ecosystem-incentive-lp-3-10", "0.1", ecosystemIncentive, ecosystemIncentiveLP, 2, 10),
+			budget("budget-ecosystem-incentive-mm-3-10", "0.3", ecosystemIncentive, ecosystemIncentiveMM, 2, 10),
+			budget("budget-ecosystem-incentive-boost-3-10", "0.6", ecosystemIncentive, ecosystemIncentiveBoost, 2, 10),
+		},
+	}.String(), genStates.BudgetParams.String())
+
+	require.Equal(t, []claimtypes.Airdrop{{
+		Id:            1,
+		SourceAddress: "cre1rq9dzurree0ruj4xvuss33ysfus3lkneg3jnfdsy4ah8gxjta3mqlr2sax",
+		Conditions: []claimtypes.ConditionType{
+			claimtypes.ConditionTypeDeposit,
+			claimtypes.ConditionTypeSwap,
+			claimtypes.ConditionTypeLiquidStake,
+			claimtypes.ConditionTypeVote,
+		},
+		StartTime: genesisTime,
+		EndTime:   genesisTime.AddDate(0, 6, 0),
+	}}, genStates.ClaimGenesisState.Airdrops)
+
+	// 50mil DEXdrop and 50mil Boostdrop, and 100mil for the foundation, the
+	// validators and the vesting accounts
+	require.Equal(t, sdk.NewInt64Coin(bondDenom, 100_000000_000000), genStates.Airdrops[0].Supply())
+	foundation := sdk.NewCoins(genStates.FoundationSupply).Add(genStates.ValidatorSupply...).Add(genStates.VestingSupply...)
+	require.Equal(t, ucre(100_000000_000000), foundation)
+	require.Equal(t, "cre1u9jxn6l7seq5jjej4w6etpdxufphwfuunljr4e", profile.Foundation.Address)
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	budgettypes "github.com/tendermint/budget/x/budget/types"
+
+	claimtypes "github.com/crescent-network/crescent/x/claim/types"
+	minttypes "github.com/crescent-network/crescent/x/mint/types"
+)
+
+const (
+	flagFrom = "from"
+	flagStep = "step"
+	flagAt   = "at"
+)
+
+// SupplyProjection is the projected supply of the mint denom at a time.
+//
+// Locked is the sum of VestingLocked, AirdropLocked, Budgets and CommunityPool,
+// and Circulating is Total minus Locked and Claimable.
+type SupplyProjection struct {
+	Time          time.Time `json:"time"`
+	Total         sdk.Int   `json:"total"`
+	Minted        sdk.Int   `json:"minted"`
+	VestingLocked sdk.Int   `json:"vesting_locked"`
+	Claimable     sdk.Int   `json:"claimable"`
+	AirdropLocked sdk.Int   `json:"airdrop_locked"`
+	Budgets       sdk.Int   `json:"budgets"`
+	CommunityPool sdk.Int   `json:"community_pool"`
+	Locked        sdk.Int   `json:"locked"`
+	Circulating   sdk.Int   `json:"circulating"`
+}
+
+func ProjectSupplyCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project-supply",
+		Args:  cobra.NoArgs,
+		Short: "Project the total, locked, claimable and circulating supply of a genesis file",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Project the total, locked, claimable and circulating supply of the mint
+denom of a genesis file over time.
+
+The supply is projected from the genesis time up to the end of the last inflation
+schedule every --%s, or at the given --%s dates. Dates and steps are RFC3339 times
+or calendar offsets from the genesis time such as 1y, 6mo or 30d.
+
+The projection assumes that:
+  - inflation is minted evenly over each inflation schedule
+  - budgets funded by the fee collector take their rate of the inflation, and the
+    budget destinations are not circulating
+  - the community tax of the remaining inflation goes to the community pool
+  - no airdrop is claimed, so the claimable amount stays claimable until the end of
+    the airdrop, when the airdrop source balance goes to the community pool
+  - the coins locked in vesting accounts are not circulating
+
+Example:
+$ %s project-supply --from ~/.crescent/config/genesis.json --step 1mo
+$ %s project-supply --from genesis.json --at 6mo,1y,2023-01-01T00:00:00Z --output json
+`,
+				flagStep, flagAt,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			genFile, err := cmd.Flags().GetString(flagFrom)
+			if err != nil {
+				return err
+			}
+			step, err := cmd.Flags().GetString(flagStep)
+			if err != nil {
+				return err
+			}
+			dates, err := cmd.Flags().GetStringSlice(flagAt)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+
+			if genFile == "" {
+				return fmt.Errorf("--%s is required", flagFrom)
+			}
+			genDoc, err := tmtypes.GenesisDocFromFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis doc from file: %w", err)
+			}
+			var appState map[string]json.RawMessage
+			if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			var times []time.Time
+			if len(dates) > 0 {
+				for _, date := range dates {
+					t, err := ParseOffsetTime(genDoc.GenesisTime, date)
+					if err != nil {
+						return fmt.Errorf("invalid --%s date %q: %w", flagAt, date, err)
+					}
+					times = append(times, t)
+				}
+			} else {
+				var mintGenState minttypes.GenesisState
+				if err := clientCtx.Codec.UnmarshalJSON(appState[minttypes.ModuleName], &mintGenState); err != nil {
+					return fmt.Errorf("failed to unmarshal mint genesis state: %w", err)
+				}
+				end := genDoc.GenesisTime
+				for _, schedule := range mintGenState.Params.InflationSchedules {
+					if schedule.EndTime.After(end) {
+						end = schedule.EndTime
+					}
+				}
+				times, err = projectionTimes(genDoc.GenesisTime, end, step)
+				if err != nil {
+					return err
+				}
+			}
+
+			projections, err := ProjectSupply(clientCtx.Codec, appState, times)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				return writeJSON(cmd.OutOrStdout(), projections)
+			case "csv":
+				records := [][]string{{"date", "total", "minted", "vesting_locked", "claimable", "airdrop_locked",
+					"budgets", "community_pool", "locked", "circulating"}}
+				for _, p := range projections {
+					records = append(records, []string{p.Time.Format(time.RFC3339), p.Total.String(), p.Minted.String(),
+						p.VestingLocked.String(), p.Claimable.String(), p.AirdropLocked.String(), p.Budgets.String(),
+						p.CommunityPool.String(), p.Locked.String(), p.Circulating.String()})
+				}
+				return csv.NewWriter(cmd.OutOrStdout()).WriteAll(records)
+			default:
+				return fmt.Errorf("unknown output format %q, expected csv or json", output)
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagFrom, "", "Genesis file to project the supply of")
+	cmd.Flags().String(flagStep, "1mo", "Calendar interval between the projected dates, e.g. 1mo, 3mo, 1y or 7d")
+	cmd.Flags().StringSlice(flagAt, nil, "Project at these dates instead of every --step")
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "csv", "Output format (csv|json)")
+
+	return cmd
+}
+
+// projectionTimes returns the times from start up to and including end every step.
+func projectionTimes(start, end time.Time, step string) ([]time.Time, error) {
+	m := offsetRegexp.FindStringSubmatch(step)
+	if m == nil {
+		return nil, fmt.Errorf("invalid step %q, expected a calendar offset such as 1mo", step)
+	}
+	n := make([]int, 3)
+	for i, v := range m[1:] {
+		if v != "" {
+			n[i], _ = strconv.Atoi(v)
+		}
+	}
+	if n[0] == 0 && n[1] == 0 && n[2] == 0 {
+		return nil, fmt.Errorf("invalid step %q, must be positive", step)
+	}
+
+	times := []time.Time{}
+	for i := 0; ; i++ {
+		// Offsets are taken from start so that e.g. monthly steps keep the day of the month
+		t := start.AddDate(i*n[0], i*n[1], i*n[2])
+		if t.After(end) {
+			break
+		}
+		times = append(times, t)
+	}
+	if last := times[len(times)-1]; last.Before(end) {
+		times = append(times, end)
+	}
+	return times, nil
+}
+
+// ProjectSupply projects the supply of the mint denom of appState at the given times.
+func ProjectSupply(cdc codec.Codec, appState map[string]json.RawMessage, times []time.Time) ([]SupplyProjection, error) {
+	var mintGenState minttypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[minttypes.ModuleName], &mintGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mint genesis state: %w", err)
+	}
+	var budgetGenState budgettypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[budgettypes.ModuleName], &budgetGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal budget genesis state: %w", err)
+	}
+	var claimGenState claimtypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[claimtypes.ModuleName], &claimGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claim genesis state: %w", err)
+	}
+	var distrGenState distrtypes.GenesisState
+	if err := cdc.UnmarshalJSON(appState[distrtypes.ModuleName], &distrGenState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal distribution genesis state: %w", err)
+	}
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+
+	denom := mintGenState.Params.MintDenom
+	schedules := mintGenState.Params.InflationSchedules
+	communityTax := distrGenState.Params.CommunityTax
+
+	genesisSupply := bankGenState.Supply.AmountOf(denom)
+	if bankGenState.Supply.Empty() {
+		for _, balance := range bankGenState.Balances {
+			genesisSupply = genesisSupply.Add(balance.Coins.AmountOf(denom))
+		}
+	}
+	genesisCommunityPool := distrGenState.FeePool.CommunityPool.AmountOf(denom).TruncateInt()
+
+	// Budgets funded by the fee collector are paid from the inflation
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName).String()
+	budgets := []budgettypes.Budget{}
+	for _, budget := range budgetGenState.Params.Budgets {
+		if budget.SourceAddress == feeCollector {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	sourceBalances := map[string]sdk.Int{}
+	for _, airdrop := range claimGenState.Airdrops {
+		sourceBalances[airdrop.SourceAddress] = sdk.ZeroInt()
+	}
+	for _, balance := range bankGenState.Balances {
+		if _, ok := sourceBalances[balance.Address]; ok {
+			sourceBalances[balance.Address] = balance.Coins.AmountOf(denom)
+		}
+	}
+	claimable := map[uint64]sdk.Int{}
+	for _, record := range claimGenState.ClaimRecords {
+		if _, ok := claimable[record.AirdropId]; !ok {
+			claimable[record.AirdropId] = sdk.ZeroInt()
+		}
+		claimable[record.AirdropId] = claimable[record.AirdropId].Add(record.ClaimableCoins.AmountOf(denom))
+	}
+
+	projections := []SupplyProjection{}
+	for _, t := range times {
+		p := SupplyProjection{
+			Time:          t,
+			Minted:        sdk.ZeroInt(),
+			VestingLocked: sdk.ZeroInt(),
+			Claimable:     sdk.ZeroInt(),
+			AirdropLocked: sdk.ZeroInt(),
+			Budgets:       sdk.ZeroInt(),
+			CommunityPool: genesisCommunityPool,
+		}
+
+		for _, schedule := range schedules {
+			p.Minted = p.Minted.Add(scheduleAmount(schedule, schedule.StartTime, t))
+			for _, budget := range budgets {
+				end := budget.EndTime
+				if t.Before(end) {
+					end = t
+				}
+				p.Budgets = p.Budgets.Add(budget.Rate.MulInt(scheduleAmount(schedule, budget.StartTime, end)).TruncateInt())
+			}
+		}
+		p.CommunityPool = p.CommunityPool.Add(communityTax.MulInt(p.Minted.Sub(p.Budgets)).TruncateInt())
+
+		for _, acc := range accs {
+			if vacc, ok := acc.(vestexported.VestingAccount); ok {
+				p.VestingLocked = p.VestingLocked.Add(vacc.GetVestingCoins(t).AmountOf(denom))
+			}
+		}
+
+		for _, airdrop := range claimGenState.Airdrops {
+			balance := sourceBalances[airdrop.SourceAddress]
+			if !t.Before(airdrop.EndTime) {
+				p.CommunityPool = p.CommunityPool.Add(balance)
+				continue
+			}
+			amt, ok := claimable[airdrop.Id]
+			if !ok {
+				amt = sdk.ZeroInt()
+			}
+			amt = sdk.MinInt(amt, balance)
+			p.Claimable = p.Claimable.Add(amt)
+			p.AirdropLocked = p.AirdropLocked.Add(balance.Sub(amt))
+		}
+
+		p.Total = genesisSupply.Add(p.Minted)
+		p.Locked = p.VestingLocked.Add(p.AirdropLocked).Add(p.Budgets).Add(p.CommunityPool)
+		p.Circulating = p.Total.Sub(p.Locked).Sub(p.Claimable)
+		projections = append(projections, p)
+	}
+
+	return projections, nil
+}
+
+// scheduleAmount returns the amount the inflation schedule mints between from and to.
+func scheduleAmount(schedule minttypes.InflationSchedule, from, to time.Time) sdk.Int {
+	if from.Before(schedule.StartTime) {
+		from = schedule.StartTime
+	}
+	if to.After(schedule.EndTime) {
+		to = schedule.EndTime
+	}
+	if !to.After(from) {
+		return sdk.ZeroInt()
+	}
+	length := schedule.EndTime.Sub(schedule.StartTime).Nanoseconds()
+	return schedule.Amount.MulRaw(to.Sub(from).Nanoseconds()).QuoRaw(length)
+}
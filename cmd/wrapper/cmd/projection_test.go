@@ -0,0 +1,33 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestProjectSupply(t *testing.T) {
+	clientCtx, appState, _, genStates := prepareTestGenesis(t)
+
+	schedules := genStates.MintParams.InflationSchedules
+	end := schedules[len(schedules)-1].EndTime
+	projections, err := cmd.ProjectSupply(clientCtx.Codec, appState, []time.Time{genStates.GenesisTime, end, end.AddDate(1, 0, 0)})
+	require.NoError(t, err)
+
+	supply := genStates.BankGenesisStates.Supply.AmountOf(genStates.BondDenom)
+	require.Equal(t, supply, projections[0].Total)
+	require.True(t, projections[0].Minted.IsZero())
+
+	minted := sdk.ZeroInt()
+	for _, schedule := range schedules {
+		minted = minted.Add(schedule.Amount)
+	}
+	for _, p := range projections[1:] {
+		require.Equal(t, supply.Add(minted), p.Total)
+		require.Equal(t, p.Total, p.Locked.Add(p.Claimable).Add(p.Circulating))
+	}
+}
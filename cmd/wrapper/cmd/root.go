@@ -92,7 +92,18 @@ func NewRootCmd() (*cobra.Command, params.EncodingConfig) {
 		genutilcli.GenTxCmd(chain.ModuleBasics, encodingConfig.TxConfig, banktypes.GenesisBalancesIterator{}, chain.DefaultNodeHome),
 		genutilcli.ValidateGenesisCmd(chain.ModuleBasics),
 		AddGenesisAccountCmd(chain.DefaultNodeHome),
+		AddGenesisAccountsCmd(chain.DefaultNodeHome),
+		UpdateGenesisAccountCmd(chain.DefaultNodeHome),
+		RemoveGenesisAccountCmd(chain.DefaultNodeHome),
 		PrepareGenesisCmd(chain.DefaultNodeHome, chain.ModuleBasics),
+		SimulateGenesisCmd(chain.DefaultNodeHome),
+		AuditCmd(chain.DefaultNodeHome),
+		VestingCmd(chain.DefaultNodeHome),
+		AirdropCmd(chain.DefaultNodeHome),
+		ProjectSupplyCmd(chain.DefaultNodeHome),
+		GenesisCmd(chain.DefaultNodeHome),
+		ValidateGenTxsCmd(chain.DefaultNodeHome),
+		VerifyGenesisCmd(chain.DefaultNodeHome),
 		keys.Commands(chain.DefaultNodeHome),
 	)
 
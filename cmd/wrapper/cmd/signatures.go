@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const (
+	flagSigners   = "signers"
+	flagThreshold = "threshold"
+)
+
+// GenesisSignature is a detached signature over the canonical hash of a genesis file.
+type GenesisSignature struct {
+	Signer        string          `json:"signer"`
+	PubKey        json.RawMessage `json:"pub_key"`
+	GenesisSHA256 string          `json:"genesis_sha256"`
+	Signature     []byte          `json:"signature"`
+}
+
+// SignatureCheck is the result of verifying the signature of an expected signer.
+type SignatureCheck struct {
+	Signer string `json:"signer"`
+	Valid  bool   `json:"valid"`
+	Status string `json:"status"`
+}
+
+// SignGenesis signs the canonical genesis hash with the key uid of kr.
+func SignGenesis(cdc codec.Codec, kr keyring.Keyring, uid string, hash []byte) (*GenesisSignature, error) {
+	sig, pubKey, err := kr.Sign(uid, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign genesis hash with key %s: %w", uid, err)
+	}
+	pubKeyJSON, err := cdc.MarshalInterfaceJSON(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return &GenesisSignature{
+		Signer:        sdk.AccAddress(pubKey.Address()).String(),
+		PubKey:        pubKeyJSON,
+		GenesisSHA256: hex.EncodeToString(hash),
+		Signature:     sig,
+	}, nil
+}
+
+// NormalizeSigners re-encodes the expected signer addresses and rejects a signer
+// given twice, which would otherwise count one signature twice toward the
+// threshold.
+func NormalizeSigners(signers []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, signer := range signers {
+		addr, err := sdk.AccAddressFromBech32(signer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer address %s: %w", signer, err)
+		}
+		if seen[addr.String()] {
+			return nil, fmt.Errorf("duplicate signer address %s", signer)
+		}
+		seen[addr.String()] = true
+		normalized = append(normalized, addr.String())
+	}
+	return normalized, nil
+}
+
+// VerifyGenesisSignatures checks the signatures of each expected signer against
+// the canonical genesis hash. A signer is valid if any of its signatures verifies.
+// Signatures of signers that are not expected are ignored, and a signer expected
+// twice is an error.
+func VerifyGenesisSignatures(cdc codec.Codec, hash []byte, sigs []GenesisSignature, signers []string) ([]SignatureCheck, error) {
+	signers, err := NormalizeSigners(signers)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []SignatureCheck{}
+	for _, signer := range signers {
+		check := SignatureCheck{Signer: signer, Status: "missing"}
+		for _, sig := range sigs {
+			if sig.Signer != check.Signer {
+				continue
+			}
+			if err := verifyGenesisSignature(cdc, hash, sig); err != nil {
+				check.Status = err.Error()
+				continue
+			}
+			check.Valid = true
+			check.Status = "valid"
+			break
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func verifyGenesisSignature(cdc codec.Codec, hash []byte, sig GenesisSignature) error {
+	if !strings.EqualFold(sig.GenesisSHA256, hex.EncodeToString(hash)) {
+		return fmt.Errorf("signed a different genesis %s", sig.GenesisSHA256)
+	}
+	var pubKey cryptotypes.PubKey
+	if err := cdc.UnmarshalInterfaceJSON(sig.PubKey, &pubKey); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if sdk.AccAddress(pubKey.Address()).String() != sig.Signer {
+		return fmt.Errorf("public key does not belong to the signer")
+	}
+	if !pubKey.VerifySignature(hash, sig.Signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// readCanonicalGenesisHash reads a genesis file and returns its canonical hash.
+func readCanonicalGenesisHash(genFile string) ([]byte, error) {
+	bz, err := os.ReadFile(genFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	if _, err := tmtypes.GenesisDocFromJSON(bz); err != nil {
+		return nil, fmt.Errorf("invalid genesis file: %w", err)
+	}
+	return CanonicalGenesisHash(bz)
+}
+
+func GenesisSignCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [genesis-file]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Sign the canonical hash of a genesis file with a keyring key",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Sign the canonical hash of a genesis file with a keyring key.
+
+The genesis file is brought into canonical form, with sorted keys and stable
+indentation, and its SHA-256 is signed with the key given by --%s. For a genesis
+written with prepare-genesis --canonical the hash is the SHA-256 of the file.
+The detached signature is written as json to --%s or to stdout.
+
+The genesis file defaults to the one in --home.
+
+Example:
+$ %s genesis sign --from foundation --output-document foundation.sig.json
+`,
+				flags.FlagFrom, flags.FlagOutputDocument,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genFile := serverCtx.Config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+
+			from, err := cmd.Flags().GetString(flags.FlagFrom)
+			if err != nil {
+				return err
+			}
+			if from == "" {
+				return fmt.Errorf("--%s is required", flags.FlagFrom)
+			}
+			keyringBackend, err := cmd.Flags().GetString(flags.FlagKeyringBackend)
+			if err != nil {
+				return err
+			}
+			outputDocument, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+			if err != nil {
+				return err
+			}
+
+			hash, err := readCanonicalGenesisHash(genFile)
+			if err != nil {
+				return err
+			}
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			kb, err := keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, inBuf)
+			if err != nil {
+				return err
+			}
+
+			sig, err := SignGenesis(clientCtx.Codec, kb, from, hash)
+			if err != nil {
+				return err
+			}
+
+			if outputDocument == "" {
+				return writeJSON(cmd.OutOrStdout(), sig)
+			}
+			var buf bytes.Buffer
+			if err := writeJSON(&buf, sig); err != nil {
+				return err
+			}
+			if err := os.WriteFile(outputDocument, buf.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write signature: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "GenesisSHA256 :", sig.GenesisSHA256)
+			fmt.Fprintln(cmd.OutOrStdout(), "Signer :", sig.Signer)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flags.FlagFrom, "", "Name of the keyring key to sign with")
+	cmd.Flags().String(flags.FlagKeyringBackend, keyring.BackendOS, "Select keyring's backend (os|file|kwallet|pass|test)")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Write the signature to the given file instead of stdout")
+
+	return cmd
+}
+
+func GenesisVerifySignaturesCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-signatures [signature-file]...",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Verify that enough expected signers signed a genesis file",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Verify that enough expected signers signed a genesis file.
+
+The signature files written by genesis sign are checked against the canonical
+hash of the genesis file given by --%s, which defaults to the one in --home.
+Only signatures of the addresses in --%s are counted, and at least --%s of them
+must be valid. The threshold defaults to all signers.
+
+Example:
+$ %s genesis verify-signatures foundation.sig.json devteam.sig.json auditor.sig.json \
+  --signers cre1...,cre1...,cre1... --threshold 2
+`,
+				flagGenesis, flagSigners, flagThreshold,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genFile, err := cmd.Flags().GetString(flagGenesis)
+			if err != nil {
+				return err
+			}
+			if genFile == "" {
+				genFile = serverCtx.Config.GenesisFile()
+			}
+			signers, err := cmd.Flags().GetStringSlice(flagSigners)
+			if err != nil {
+				return err
+			}
+			if len(signers) == 0 {
+				return fmt.Errorf("--%s is required", flagSigners)
+			}
+			if signers, err = NormalizeSigners(signers); err != nil {
+				return err
+			}
+			threshold, err := cmd.Flags().GetInt(flagThreshold)
+			if err != nil {
+				return err
+			}
+			if threshold == 0 {
+				threshold = len(signers)
+			}
+			if threshold < 0 || threshold > len(signers) {
+				return fmt.Errorf("threshold %d must be between 1 and the number of signers %d", threshold, len(signers))
+			}
+
+			hash, err := readCanonicalGenesisHash(genFile)
+			if err != nil {
+				return err
+			}
+
+			sigs := []GenesisSignature{}
+			for _, path := range args {
+				bz, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read signature file: %w", err)
+				}
+				var sig GenesisSignature
+				if err := json.Unmarshal(bz, &sig); err != nil {
+					return fmt.Errorf("failed to unmarshal signature file %s: %w", path, err)
+				}
+				sigs = append(sigs, sig)
+			}
+
+			checks, err := VerifyGenesisSignatures(clientCtx.Codec, hash, sigs, signers)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "GenesisSHA256 :", hex.EncodeToString(hash))
+			valid := 0
+			for _, check := range checks {
+				if check.Valid {
+					valid++
+				}
+				fmt.Fprintf(out, "%s : %s\n", check.Signer, check.Status)
+			}
+			fmt.Fprintf(out, "%d of %d signers, threshold %d\n", valid, len(checks), threshold)
+
+			if valid < threshold {
+				return fmt.Errorf("only %d valid signatures, %d required", valid, threshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagGenesis, "", "Genesis file to verify instead of the one in --home")
+	cmd.Flags().StringSlice(flagSigners, nil, "Comma separated addresses of the expected signers")
+	cmd.Flags().Int(flagThreshold, 0, "Number of valid signatures required, all signers if 0")
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestVerifyGenesisSignatures(t *testing.T) {
+	cdc := newTestClientCtx(t).Codec
+
+	kr := keyring.NewInMemory()
+	signers := []string{}
+	for _, uid := range []string{"foundation", "devteam", "auditor"} {
+		info, _, err := kr.NewMnemonic(uid, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		require.NoError(t, err)
+		signers = append(signers, info.GetAddress().String())
+	}
+
+	genesis := []byte(`{"chain_id":"crescent-1","app_state":{"b":1,"a":"x"}}`)
+	hash, err := cmd.CanonicalGenesisHash(genesis)
+	require.NoError(t, err)
+	reformatted, err := cmd.CanonicalGenesisHash([]byte("{\"app_state\": {\"a\": \"x\", \"b\": 1},\n \"chain_id\": \"crescent-1\"}"))
+	require.NoError(t, err)
+	require.Equal(t, hash, reformatted)
+	other, err := cmd.CanonicalGenesisHash([]byte(`{"chain_id":"crescent-1","app_state":{"b":2,"a":"x"}}`))
+	require.NoError(t, err)
+
+	foundation, err := cmd.SignGenesis(cdc, kr, "foundation", hash)
+	require.NoError(t, err)
+	devteam, err := cmd.SignGenesis(cdc, kr, "devteam", other)
+	require.NoError(t, err)
+	forged := *foundation
+	forged.Signer = signers[2]
+
+	checks, err := cmd.VerifyGenesisSignatures(cdc, hash, []cmd.GenesisSignature{*foundation, *devteam, forged}, signers)
+	require.NoError(t, err)
+	require.Len(t, checks, 3)
+	require.True(t, checks[0].Valid)
+	require.False(t, checks[1].Valid)
+	require.Contains(t, checks[1].Status, "different genesis")
+	require.False(t, checks[2].Valid)
+	require.Contains(t, checks[2].Status, "public key")
+
+	auditor, err := cmd.SignGenesis(cdc, kr, "auditor", hash)
+	require.NoError(t, err)
+	checks, err = cmd.VerifyGenesisSignatures(cdc, hash, []cmd.GenesisSignature{forged, *auditor}, signers)
+	require.NoError(t, err)
+	require.Equal(t, "missing", checks[1].Status)
+	require.True(t, checks[2].Valid)
+
+	// A signer given twice would count its signature twice
+	_, err = cmd.VerifyGenesisSignatures(cdc, hash, []cmd.GenesisSignature{*foundation}, []string{signers[0], signers[0]})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate signer")
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	chain "github.com/crescent-network/crescent/app"
+)
+
+const (
+	flagBlocks    = "blocks"
+	flagBlockTime = "block-time"
+	flagGentxDir  = "gentx-dir"
+)
+
+// SimulationResult is the outcome of running a genesis file in-process.
+type SimulationResult struct {
+	ChainId          string
+	Validators       int
+	TotalPower       int64
+	Height           int64
+	AppHash          []byte
+	Invariants       int
+	BrokenInvariants []string
+}
+
+// simulateAppOptions is a minimal servertypes.AppOptions for the in-memory app.
+type simulateAppOptions map[string]interface{}
+
+func (o simulateAppOptions) Get(key string) interface{} {
+	return o[key]
+}
+
+func SimulateGenesisCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-genesis [genesis-file]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Run InitChain and a number of empty blocks with a genesis file in-process",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Run InitChain and a number of empty blocks with a genesis file in-process.
+
+The Crescent app is built against an in-memory database, so no network or
+node data is needed. Gentxs included in the genesis file are delivered during
+InitChain; more can be added from a directory with --gentx-dir. All crisis
+invariants are checked after InitChain and after the last block.
+
+The genesis file defaults to the one in --home.
+
+Example:
+$ %s simulate-genesis
+$ %s simulate-genesis ./genesis.json --gentx-dir ./gentxs --blocks 20
+`,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			genFile := serverCtx.Config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+
+			blocks, err := cmd.Flags().GetInt64(flagBlocks)
+			if err != nil {
+				return err
+			}
+			blockTime, err := cmd.Flags().GetDuration(flagBlockTime)
+			if err != nil {
+				return err
+			}
+			gentxDir, err := cmd.Flags().GetString(flagGentxDir)
+			if err != nil {
+				return err
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis doc from file: %w", err)
+			}
+
+			if gentxDir != "" {
+				if err := addGenTxsFromDir(clientCtx, genDoc, gentxDir); err != nil {
+					return err
+				}
+			}
+
+			result, err := SimulateGenesis(genDoc, blocks, blockTime)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "ChainId :", result.ChainId)
+			fmt.Fprintln(out, "Validators :", result.Validators)
+			fmt.Fprintln(out, "TotalPower :", result.TotalPower)
+			fmt.Fprintln(out, "Height :", result.Height)
+			fmt.Fprintf(out, "AppHash : %X\n", result.AppHash)
+			fmt.Fprintln(out, "Invariants :", result.Invariants)
+			for _, msg := range result.BrokenInvariants {
+				fmt.Fprintln(out, "broken invariant:", msg)
+			}
+			if len(result.BrokenInvariants) > 0 {
+				return fmt.Errorf("%d invariants are broken", len(result.BrokenInvariants))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(flagBlocks, 10, "Number of empty blocks to run after InitChain")
+	cmd.Flags().Duration(flagBlockTime, 5*time.Second, "Time between the simulated blocks")
+	cmd.Flags().String(flagGentxDir, "", "Directory of gentx json files to add to the genesis file")
+
+	return cmd
+}
+
+// SimulateGenesis builds the Crescent app on an in-memory database, runs InitChain
+// with genDoc followed by the given number of empty blocks, and checks all crisis
+// invariants after InitChain and after the last block. Panics raised by modules
+// are returned as errors.
+func SimulateGenesis(genDoc *tmtypes.GenesisDoc, blocks int64, blockTime time.Duration) (result *SimulationResult, err error) {
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid genesis doc: %w", err)
+	}
+
+	result = &SimulationResult{ChainId: genDoc.ChainID, Height: genDoc.InitialHeight - 1}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("simulation panicked at height %d: %v", result.Height, r)
+			result = nil
+		}
+	}()
+
+	// Invariants are checked below so that all broken ones are reported
+	// rather than only the first one in crisis InitGenesis
+	app := chain.NewApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, os.TempDir(), 0,
+		chain.MakeEncodingConfig(), simulateAppOptions{crisis.FlagSkipGenesisInvariants: true},
+	)
+
+	res := app.InitChain(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		InitialHeight:   genDoc.InitialHeight,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		AppStateBytes:   genDoc.AppState,
+	})
+
+	validators := map[string]abci.ValidatorUpdate{}
+	for _, update := range res.Validators {
+		validators[update.PubKey.String()] = update
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("genesis has no validators after InitChain, gentxs are missing or failed")
+	}
+
+	header := tmproto.Header{
+		ChainID: genDoc.ChainID,
+		Height:  genDoc.InitialHeight - 1,
+		Time:    genDoc.GenesisTime,
+	}
+	// The state of InitChain is not committed yet
+	ctx := app.BaseApp.NewContext(false, header)
+	result.BrokenInvariants = append(result.BrokenInvariants, checkInvariants(app, ctx)...)
+
+	var lastCommit abci.LastCommitInfo
+	for i := int64(0); i < blocks; i++ {
+		votes, proposer, err := simulateVotes(validators)
+		if err != nil {
+			return nil, err
+		}
+
+		header.Height++
+		if i > 0 {
+			header.Time = header.Time.Add(blockTime)
+		}
+		header.ProposerAddress = proposer
+		result.Height = header.Height
+
+		app.BeginBlock(abci.RequestBeginBlock{Header: header, LastCommitInfo: lastCommit})
+		endRes := app.EndBlock(abci.RequestEndBlock{Height: header.Height})
+		commitRes := app.Commit()
+		header.AppHash = commitRes.Data
+		result.AppHash = commitRes.Data
+
+		lastCommit = abci.LastCommitInfo{Votes: votes}
+		for _, update := range endRes.ValidatorUpdates {
+			if update.Power == 0 {
+				delete(validators, update.PubKey.String())
+			} else {
+				validators[update.PubKey.String()] = update
+			}
+		}
+		if len(validators) == 0 {
+			return nil, fmt.Errorf("validator set is empty at height %d", header.Height)
+		}
+	}
+
+	ctx = app.BaseApp.NewUncachedContext(false, header)
+	result.BrokenInvariants = append(result.BrokenInvariants, checkInvariants(app, ctx)...)
+	result.Invariants = len(app.CrisisKeeper.Routes())
+	result.Validators = len(validators)
+	for _, update := range validators {
+		result.TotalPower += update.Power
+	}
+
+	return result, nil
+}
+
+// simulateVotes returns a commit in which every validator signed, and the
+// address of the validator with the most power as the proposer.
+func simulateVotes(validators map[string]abci.ValidatorUpdate) ([]abci.VoteInfo, []byte, error) {
+	votes := []abci.VoteInfo{}
+	for _, update := range validators {
+		pubKey, err := cryptocodec.FromTmProtoPublicKey(update.PubKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid validator pubkey: %w", err)
+		}
+		votes = append(votes, abci.VoteInfo{
+			Validator:       abci.Validator{Address: pubKey.Address(), Power: update.Power},
+			SignedLastBlock: true,
+		})
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		if votes[i].Validator.Power != votes[j].Validator.Power {
+			return votes[i].Validator.Power > votes[j].Validator.Power
+		}
+		return string(votes[i].Validator.Address) < string(votes[j].Validator.Address)
+	})
+	return votes, votes[0].Validator.Address, nil
+}
+
+// checkInvariants runs every registered crisis invariant and returns the
+// messages of the broken ones.
+func checkInvariants(app *chain.App, ctx sdk.Context) []string {
+	broken := []string{}
+	for _, route := range app.CrisisKeeper.Routes() {
+		if msg, stop := route.Invar(ctx); stop {
+			broken = append(broken, fmt.Sprintf("height %d: %s", ctx.BlockHeight(), strings.TrimSpace(msg)))
+		}
+	}
+	return broken
+}
+
+// addGenTxsFromDir appends the gentxs in dir to the genutil genesis state of genDoc.
+func addGenTxsFromDir(clientCtx client.Context, genDoc *tmtypes.GenesisDoc, dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read gentx directory: %w", err)
+	}
+
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+	}
+	genTxState := genutiltypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read gentx %s: %w", path, err)
+		}
+		// Decode to make sure the file is a transaction before adding it
+		if _, err := clientCtx.TxConfig.TxJSONDecoder()(bz); err != nil {
+			return fmt.Errorf("failed to decode gentx %s: %w", path, err)
+		}
+		genTxState.GenTxs = append(genTxState.GenTxs, json.RawMessage(bz))
+	}
+
+	genTxStateBz, err := clientCtx.Codec.MarshalJSON(genTxState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal genutil genesis state: %w", err)
+	}
+	appState[genutiltypes.ModuleName] = genTxStateBz
+
+	genDoc.AppState, err = json.Marshal(appState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal application genesis state: %w", err)
+	}
+	return nil
+}
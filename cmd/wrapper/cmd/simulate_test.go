@@ -0,0 +1,46 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestSimulateGenesisWithoutValidators(t *testing.T) {
+	_, _, genDoc, _ := prepareTestGenesis(t)
+
+	// InitChain runs every module but no gentx creates a validator
+	_, err := cmd.SimulateGenesis(genDoc, 1, time.Second)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no validators")
+}
+
+func TestSimulateGenesis(t *testing.T) {
+	clientCtx := newTestClientCtx(t)
+
+	kr := keyring.NewInMemory()
+	validator, genTx := signGenTx(t, clientCtx, kr, "mooncat-1-1", "validator", "validator", "0.1", sdk.NewInt64Coin("utcre", 1000000))
+
+	profile, err := cmd.BuiltinProfile("testnet")
+	require.NoError(t, err)
+	profile.Balances = append(profile.Balances, cmd.BalanceProfile{Address: validator.String(), Coins: "1000000utcre"})
+	appState, genDoc, _ := prepareProfileGenesis(t, clientCtx, profile, "mooncat-1-1")
+	genTxState := genutiltypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	genTxState.GenTxs = append(genTxState.GenTxs, genTx)
+	appState[genutiltypes.ModuleName] = clientCtx.Codec.MustMarshalJSON(genTxState)
+	genDoc.AppState, err = json.Marshal(appState)
+	require.NoError(t, err)
+
+	result, err := cmd.SimulateGenesis(genDoc, 3, 5*time.Second)
+	require.NoError(t, err)
+	require.Empty(t, result.BrokenInvariants)
+	require.Equal(t, int64(3), result.Height)
+	require.Equal(t, 1, result.Validators)
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+const (
+	flagGenesis = "genesis"
+	flagTotals  = "totals"
+)
+
+// UnlockEvent is an amount of a vesting account that unlocks at a time.
+// Cumulative is the amount unlocked by all accounts up to and including the event.
+type UnlockEvent struct {
+	Time       time.Time `json:"time"`
+	Address    string    `json:"address"`
+	Amount     sdk.Coins `json:"amount"`
+	Cumulative sdk.Coins `json:"cumulative"`
+}
+
+// MonthlyUnlock is the amount unlocked by all accounts in a calendar month.
+type MonthlyUnlock struct {
+	Month      string    `json:"month"`
+	Amount     sdk.Coins `json:"amount"`
+	Cumulative sdk.Coins `json:"cumulative"`
+}
+
+// VestingCmd groups the commands inspecting vesting accounts.
+func VestingCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vesting",
+		Short: "Vesting account subcommands",
+		RunE:  client.ValidateCmd,
+	}
+
+	cmd.AddCommand(VestingScheduleCmd(defaultNodeHome))
+
+	return cmd
+}
+
+func VestingScheduleCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Args:  cobra.NoArgs,
+		Short: "Export the unlock calendar of vesting accounts",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Export the unlock calendar of vesting accounts as csv or json.
+
+The vesting accounts are read from a genesis file with --%s, or from a vesting
+file with --%s. A vesting file is parsed with the bond denom, genesis time and
+schedules of the builtin network given by --%s or of the profile given by --%s.
+
+Every unlock event is listed with its date, address, amount and the cumulative
+amount unlocked by all accounts. Continuous vesting accounts are listed at the
+start of every calendar month and at their end time. Permanent locked accounts
+never unlock and are not listed. With --%s the events are summed per month.
+
+Example:
+$ %s vesting schedule --genesis ~/.crescent/config/genesis.json --totals
+$ %s vesting schedule --vesting-file ./vesting.csv --network mainnet --output json
+`,
+				flagGenesis, flagVestingFile, flagNetwork, flagProfile, flagTotals,
+				version.AppName,
+				version.AppName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			genFile, err := cmd.Flags().GetString(flagGenesis)
+			if err != nil {
+				return err
+			}
+			vestingFile, err := cmd.Flags().GetString(flagVestingFile)
+			if err != nil {
+				return err
+			}
+			network, err := cmd.Flags().GetString(flagNetwork)
+			if err != nil {
+				return err
+			}
+			profilePath, err := cmd.Flags().GetString(flagProfile)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+			totals, err := cmd.Flags().GetBool(flagTotals)
+			if err != nil {
+				return err
+			}
+
+			var vestingAccs []VestingGenesisAccount
+			switch {
+			case genFile != "" && vestingFile != "":
+				return fmt.Errorf("--%s cannot be used together with --%s", flagGenesis, flagVestingFile)
+			case genFile != "":
+				vestingAccs, err = vestingAccountsFromGenesis(clientCtx, genFile)
+			case vestingFile != "":
+				vestingAccs, err = vestingAccountsFromFile(vestingFile, network, profilePath)
+			default:
+				return fmt.Errorf("either --%s or --%s must be given", flagGenesis, flagVestingFile)
+			}
+			if err != nil {
+				return err
+			}
+
+			events := VestingUnlocks(vestingAccs)
+			if totals {
+				return writeMonthlyUnlocks(cmd.OutOrStdout(), MonthlyUnlocks(events), output)
+			}
+			return writeUnlockEvents(cmd.OutOrStdout(), events, output)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagGenesis, "", "Genesis file to read the vesting accounts from")
+	cmd.Flags().String(flagVestingFile, "", "Vesting csv file to read the vesting accounts from")
+	cmd.Flags().String(flagNetwork, "mainnet", "Builtin network type used to parse the vesting file (mainnet|testnet)")
+	cmd.Flags().String(flagProfile, "", "Network profile used to parse the vesting file instead of --network")
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "csv", "Output format (csv|json)")
+	cmd.Flags().Bool(flagTotals, false, "Sum the unlocked amounts per month")
+
+	return cmd
+}
+
+func vestingAccountsFromGenesis(clientCtx client.Context, genFile string) ([]VestingGenesisAccount, error) {
+	genDoc, err := tmtypes.GenesisDocFromFile(genFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis doc from file: %w", err)
+	}
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis state: %w", err)
+	}
+
+	authGenState := authtypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+
+	vestingAccs := []VestingGenesisAccount{}
+	for _, acc := range accs {
+		if vestingAcc, ok := acc.(VestingGenesisAccount); ok {
+			vestingAccs = append(vestingAccs, vestingAcc)
+		}
+	}
+	return vestingAccs, nil
+}
+
+func vestingAccountsFromFile(vestingFile, network, profilePath string) ([]VestingGenesisAccount, error) {
+	var profile *Profile
+	var err error
+	if profilePath != "" {
+		profile, err = LoadProfile(profilePath)
+	} else {
+		profile, err = parseNetworkType(network)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTime, err := ParseTime(profile.GenesisTime)
+	if err != nil {
+		return nil, fmt.Errorf("genesis_time: %w", err)
+	}
+	schedules, err := profile.VestingSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := profile.AddressPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, vestingAccs, _, err := ParseVestingAccounts(vestingFile, profile.BondDenom, genesisTime, schedules, addresses)
+	return vestingAccs, err
+}
+
+// VestingUnlocks returns the unlock events of the vesting accounts ordered by
+// time and address.
+func VestingUnlocks(vestingAccs []VestingGenesisAccount) []UnlockEvent {
+	events := []UnlockEvent{}
+	for _, acc := range vestingAccs {
+		vested := sdk.Coins{}
+		for _, t := range unlockTimes(acc) {
+			now := acc.GetVestedCoins(t)
+			amount := now.Sub(vested)
+			vested = now
+			if amount.IsZero() {
+				continue
+			}
+			events = append(events, UnlockEvent{
+				Time:    t,
+				Address: acc.GetAddress().String(),
+				Amount:  amount,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].Address < events[j].Address
+	})
+
+	cumulative := sdk.Coins{}
+	for i := range events {
+		cumulative = cumulative.Add(events[i].Amount...)
+		events[i].Cumulative = cumulative
+	}
+	return events
+}
+
+// unlockTimes returns the times at which acc unlocks coins.
+func unlockTimes(acc VestingGenesisAccount) []time.Time {
+	times := []time.Time{}
+	switch acc := acc.(type) {
+	case *authvesting.PeriodicVestingAccount:
+		t := acc.StartTime
+		for _, period := range acc.VestingPeriods {
+			t += period.Length
+			times = append(times, time.Unix(t, 0).UTC())
+		}
+	case *authvesting.ContinuousVestingAccount:
+		start, end := time.Unix(acc.StartTime, 0).UTC(), time.Unix(acc.EndTime, 0).UTC()
+		month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+		for ; month.Before(end); month = month.AddDate(0, 1, 0) {
+			times = append(times, month)
+		}
+		times = append(times, end)
+	case *authvesting.DelayedVestingAccount:
+		times = append(times, time.Unix(acc.EndTime, 0).UTC())
+	}
+	return times
+}
+
+// MonthlyUnlocks sums the unlock events per calendar month.
+func MonthlyUnlocks(events []UnlockEvent) []MonthlyUnlock {
+	months := []MonthlyUnlock{}
+	for _, event := range events {
+		month := event.Time.Format("2006-01")
+		if len(months) == 0 || months[len(months)-1].Month != month {
+			months = append(months, MonthlyUnlock{Month: month, Amount: sdk.Coins{}})
+		}
+		last := &months[len(months)-1]
+		last.Amount = last.Amount.Add(event.Amount...)
+		last.Cumulative = event.Cumulative
+	}
+	return months
+}
+
+func writeUnlockEvents(w io.Writer, events []UnlockEvent, output string) error {
+	switch output {
+	case "json":
+		return writeJSON(w, events)
+	case "csv":
+		records := [][]string{{"date", "address", "amount", "cumulative"}}
+		for _, event := range events {
+			records = append(records, []string{
+				event.Time.Format(time.RFC3339), event.Address, event.Amount.String(), event.Cumulative.String(),
+			})
+		}
+		return csv.NewWriter(w).WriteAll(records)
+	default:
+		return fmt.Errorf("unknown output format %q, expected csv or json", output)
+	}
+}
+
+func writeMonthlyUnlocks(w io.Writer, months []MonthlyUnlock, output string) error {
+	switch output {
+	case "json":
+		return writeJSON(w, months)
+	case "csv":
+		records := [][]string{{"month", "amount", "cumulative"}}
+		for _, month := range months {
+			records = append(records, []string{month.Month, month.Amount.String(), month.Cumulative.String()})
+		}
+		return csv.NewWriter(w).WriteAll(records)
+	default:
+		return fmt.Errorf("unknown output format %q, expected csv or json", output)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
@@ -0,0 +1,39 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestVestingUnlocks(t *testing.T) {
+	genesisTime := time.Date(2022, 4, 13, 0, 0, 0, 0, time.UTC)
+	schedules := cmd.VestingSchedules{cmd.DefaultVestingScheduleName: cmd.StandardVestingSchedule()}
+
+	_, _, vestingAccs, _, err := cmd.ParseVestingAccounts(vestingFilePathTest, bondDenom, genesisTime, schedules, nil)
+	require.NoError(t, err)
+
+	// A continuous account unlocking over 2 months from the middle of a month
+	addr := sdk.AccAddress(make([]byte, 20))
+	start := time.Date(2022, 4, 16, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 6, 16, 0, 0, 0, 0, time.UTC)
+	vestingAccs = append(vestingAccs, authvesting.NewContinuousVestingAccount(authtypes.NewBaseAccount(addr, nil, 0, 0),
+		sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 6100)), start.Unix(), end.Unix()))
+
+	events := cmd.VestingUnlocks(vestingAccs)
+	require.Len(t, events, 25*2+3)
+	require.Equal(t, time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), events[0].Time)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1500)), events[0].Amount)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 200006100)), events[len(events)-1].Cumulative)
+
+	months := cmd.MonthlyUnlocks(events)
+	require.Len(t, months, 25+2)
+	require.Equal(t, "2022-05", months[0].Month)
+	require.Equal(t, events[len(events)-1].Cumulative, months[len(months)-1].Cumulative)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+// Vesting lengths are fixed: a year is 365 days and a month is a twelfth of a year.
+const (
+	VestingYear  = int64(60 * 60 * 24 * 365) // 31,536,000
+	VestingMonth = VestingYear / 12          // 2,628,000
+)
+
+// Where the rounding remainder of a vesting schedule goes.
+const (
+	RemainderFirst = "first" // added to the first tranche
+	RemainderLast  = "last"  // added to the last tranche
+)
+
+// DefaultVestingScheduleName is the schedule used for vesting rows that do not name one.
+const DefaultVestingScheduleName = "default"
+
+// VestingPhase is a run of equal tranches unlocking Ratio of the total amount.
+type VestingPhase struct {
+	Ratio    sdk.Dec
+	Tranches int
+	Interval int64 // seconds between tranches, the first one unlocks one interval after the phase starts
+}
+
+// VestingSchedule is a named template turning a vesting amount into periods.
+type VestingSchedule struct {
+	Name      string
+	Cliff     int64 // seconds added before the first tranche
+	Phases    []VestingPhase
+	Remainder string
+}
+
+// VestingSchedules maps schedule names to templates.
+type VestingSchedules map[string]*VestingSchedule
+
+// StandardVestingSchedule is the 3 year schedule of the original mainnet vesting:
+// 34% after 1 year, then 34% and 32% in monthly tranches over the 2nd and 3rd year.
+func StandardVestingSchedule() *VestingSchedule {
+	return &VestingSchedule{
+		Name: DefaultVestingScheduleName,
+		Phases: []VestingPhase{
+			{Ratio: sdk.MustNewDecFromStr("0.34"), Tranches: 1, Interval: VestingYear},
+			{Ratio: sdk.MustNewDecFromStr("0.34"), Tranches: 12, Interval: VestingMonth},
+			{Ratio: sdk.MustNewDecFromStr("0.32"), Tranches: 12, Interval: VestingMonth},
+		},
+		Remainder: RemainderFirst,
+	}
+}
+
+// Validate checks that the phases are well formed and their ratios add up to 1.
+func (s *VestingSchedule) Validate() error {
+	if len(s.Phases) == 0 {
+		return fmt.Errorf("vesting schedule %s has no phases", s.Name)
+	}
+	if s.Cliff < 0 {
+		return fmt.Errorf("vesting schedule %s has a negative cliff", s.Name)
+	}
+	totalRatio := sdk.ZeroDec()
+	for i, phase := range s.Phases {
+		if !phase.Ratio.IsPositive() {
+			return fmt.Errorf("vesting schedule %s phase %d: ratio must be positive", s.Name, i)
+		}
+		if phase.Tranches < 1 {
+			return fmt.Errorf("vesting schedule %s phase %d: tranches must be at least 1", s.Name, i)
+		}
+		if phase.Interval <= 0 {
+			return fmt.Errorf("vesting schedule %s phase %d: interval must be positive", s.Name, i)
+		}
+		totalRatio = totalRatio.Add(phase.Ratio)
+	}
+	if !totalRatio.Equal(sdk.OneDec()) {
+		return fmt.Errorf("vesting schedule %s ratios add up to %s, expected 1", s.Name, totalRatio)
+	}
+	if s.Remainder != RemainderFirst && s.Remainder != RemainderLast {
+		return fmt.Errorf("vesting schedule %s: unknown remainder %q, expected %s or %s",
+			s.Name, s.Remainder, RemainderFirst, RemainderLast)
+	}
+	return nil
+}
+
+// Length returns the total vesting length in seconds.
+func (s *VestingSchedule) Length() int64 {
+	length := s.Cliff
+	for _, phase := range s.Phases {
+		length += phase.Interval * int64(phase.Tranches)
+	}
+	return length
+}
+
+// Periods splits totalVestingAmount of denom into the periods of the schedule.
+// The amount of a tranche is truncated, and what is left over from truncation
+// is added to the first or last tranche.
+func (s *VestingSchedule) Periods(totalVestingAmount sdk.Int, denom string) (authvesting.Periods, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	lengths := []int64{}
+	amounts := []sdk.Int{}
+	for _, phase := range s.Phases {
+		trancheAmount := totalVestingAmount.ToDec().MulTruncate(phase.Ratio).QuoInt64(int64(phase.Tranches)).TruncateInt()
+		for i := 0; i < phase.Tranches; i++ {
+			lengths = append(lengths, phase.Interval)
+			amounts = append(amounts, trancheAmount)
+		}
+	}
+	lengths[0] += s.Cliff
+
+	totalAmount := sdk.ZeroInt()
+	for _, amount := range amounts {
+		totalAmount = totalAmount.Add(amount)
+	}
+	crumb := totalVestingAmount.Sub(totalAmount)
+	if crumb.IsNegative() {
+		return nil, fmt.Errorf("vesting schedule %s exceeds the total vesting amount %s", s.Name, totalVestingAmount)
+	}
+	if s.Remainder == RemainderFirst {
+		amounts[0] = amounts[0].Add(crumb)
+	} else {
+		amounts[len(amounts)-1] = amounts[len(amounts)-1].Add(crumb)
+	}
+
+	periods := authvesting.Periods{}
+	totalLength := int64(0)
+	totalAmount = sdk.ZeroInt()
+	for i := range amounts {
+		periods = append(periods, authvesting.Period{
+			Length: lengths[i],
+			Amount: sdk.NewCoins(sdk.NewCoin(denom, amounts[i])),
+		})
+		totalLength += lengths[i]
+		totalAmount = totalAmount.Add(amounts[i])
+	}
+
+	if totalLength != s.Length() {
+		return nil, fmt.Errorf("invalid total vesting length %d, expected %d", totalLength, s.Length())
+	}
+	if !totalAmount.Equal(totalVestingAmount) {
+		return nil, fmt.Errorf("invalid total vesting amount %s, expected %s", totalAmount, totalVestingAmount)
+	}
+	return periods, nil
+}
+
+// Get returns the schedule with the given name.
+func (s VestingSchedules) Get(name string) (*VestingSchedule, error) {
+	schedule, ok := s[name]
+	if !ok {
+		names := []string{}
+		for name := range s {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown vesting schedule %q, expected one of %v", name, names)
+	}
+	return schedule, nil
+}
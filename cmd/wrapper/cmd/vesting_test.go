@@ -0,0 +1,32 @@
+package cmd_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crescent-network/genesis-wrapper/cmd/wrapper/cmd"
+)
+
+func TestVestingSchedulePeriods(t *testing.T) {
+	// 6 month cliff, then 4 quarterly tranches of 25% with the remainder in the last
+	schedule := &cmd.VestingSchedule{
+		Name:      "investor",
+		Cliff:     cmd.VestingMonth * 6,
+		Phases:    []cmd.VestingPhase{{Ratio: sdk.OneDec(), Tranches: 4, Interval: cmd.VestingMonth * 3}},
+		Remainder: cmd.RemainderLast,
+	}
+	periods, err := schedule.Periods(sdk.NewInt(1003), bondDenom)
+	require.NoError(t, err)
+	require.Len(t, periods, 4)
+	require.Equal(t, cmd.VestingMonth*9, periods[0].Length)
+	require.Equal(t, cmd.VestingMonth*3, periods[3].Length)
+	require.Equal(t, cmd.VestingYear+cmd.VestingMonth*6, schedule.Length())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 250)), periods[0].Amount)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 253)), periods[3].Amount)
+
+	schedule.Phases[0].Ratio = sdk.MustNewDecFromStr("0.9")
+	_, err = schedule.Periods(sdk.NewInt(1003), bondDenom)
+	require.Error(t, err)
+}